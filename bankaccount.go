@@ -0,0 +1,118 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	PaymentTypeCreditCard  = "credit_card"
+	PaymentTypeBankAccount = "bank_account"
+	PaymentTypePaypal      = "paypal_account"
+)
+
+type BankAccountStatus string
+
+const (
+	BankAccountPending  BankAccountStatus = "pending"
+	BankAccountVerified BankAccountStatus = "verified"
+	BankAccountFailed   BankAccountStatus = "failed"
+)
+
+type BankAccountVerification struct {
+	Deposit1InCents int64 `json:"deposit_1_in_cents"`
+	Deposit2InCents int64 `json:"deposit_2_in_cents"`
+}
+
+// Create a standalone bank account payment profile for a customer, from a Chargify.js token or raw account details.
+func CreateBankAccountProfile(client Client, customerID int64, account *BankAccount) (response *PaymentProfile, err error) {
+	if customerID == 0 {
+		return nil, NoID()
+	}
+	if account == nil {
+		return nil, errors.New("missing request")
+	}
+	if account.ChargifyToken == "" && account.BankAccountNumber == "" && account.BankIBAN == "" {
+		return nil, errors.New("no chargify token or bank account number present")
+	}
+	if account.ChargifyToken == "" && account.BankAccountNumber != "" && account.BankRoutingNumber == "" {
+		return nil, errors.New("no bank routing number present")
+	}
+	pp := &PaymentProfile{
+		CustomerID:            customerID,
+		ChargifyToken:         account.ChargifyToken,
+		PaymentType:           PaymentTypeBankAccount,
+		BankName:              account.BankName,
+		BankRoutingNumber:     account.BankRoutingNumber,
+		BankAccountNumber:     account.BankAccountNumber,
+		BankAccountType:       account.BankAccountType,
+		BankBranchCode:        account.BankBranchCode,
+		BankIBAN:              account.BankIBAN,
+		BankAccountHolderType: account.BankAccountHolderType,
+	}
+	return pp.Create(client)
+}
+
+// Confirm the two micro-deposits Chargify sent to a bank account.
+func VerifyBankAccount(client Client, paymentProfileID int64, verification *BankAccountVerification) (response *PaymentProfile, err error) {
+	if paymentProfileID == 0 {
+		return nil, NoID()
+	}
+	if verification == nil {
+		return nil, errors.New("missing request")
+	}
+	// micro-deposits are always under a dollar
+	for _, deposit := range []int64{verification.Deposit1InCents, verification.Deposit2InCents} {
+		if deposit < 1 || deposit > 99 {
+			return nil, errors.New("deposits must be between 1 and 99 cents")
+		}
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		BankAccountVerification *BankAccountVerification `json:"bank_account_verification"`
+	}{
+		BankAccountVerification: verification,
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("bank_accounts/%d/verification.json", paymentProfileID)
+	var res *http.Response
+	res, err = client.Put(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nested := new(PaymentProfileRequest)
+	if err = json.Unmarshal(body, nested); err != nil {
+		return
+	}
+	return nested.PaymentProfile, nil
+}
+
+// BankAccountStatus reports the verification status of a bank account payment profile.
+// Profiles that are not bank accounts report an empty status.
+func (pp *PaymentProfile) BankAccountStatus() BankAccountStatus {
+	if pp.PaymentType != PaymentTypeBankAccount {
+		return ""
+	}
+	switch {
+	case pp.Verified:
+		return BankAccountVerified
+	case pp.Disabled:
+		return BankAccountFailed
+	default:
+		return BankAccountPending
+	}
+}
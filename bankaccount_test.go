@@ -0,0 +1,75 @@
+package chargify
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVerifyBankAccount(t *testing.T) {
+	tests := []struct {
+		name             string
+		paymentProfileID int64
+		verification     *BankAccountVerification
+		wantErr          error
+	}{
+		{
+			name:         "no id",
+			verification: &BankAccountVerification{Deposit1InCents: 32, Deposit2InCents: 45},
+			wantErr:      NoID(),
+		},
+		{
+			name:             "no request",
+			paymentProfileID: 1,
+			wantErr:          errors.New("missing request"),
+		},
+		{
+			name:             "deposit out of range",
+			paymentProfileID: 1,
+			verification:     &BankAccountVerification{Deposit1InCents: 32, Deposit2InCents: 450},
+			wantErr:          errors.New("deposits must be between 1 and 99 cents"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := VerifyBankAccount(nil, tt.paymentProfileID, tt.verification); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("VerifyBankAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPaymentProfile_BankAccountStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		pp   *PaymentProfile
+		want BankAccountStatus
+	}{
+		{
+			name: "card",
+			pp:   &PaymentProfile{PaymentType: PaymentTypeCreditCard},
+		},
+		{
+			name: "pending",
+			pp:   &PaymentProfile{PaymentType: PaymentTypeBankAccount},
+			want: BankAccountPending,
+		},
+		{
+			name: "verified",
+			pp:   &PaymentProfile{PaymentType: PaymentTypeBankAccount, Verified: true},
+			want: BankAccountVerified,
+		},
+		{
+			name: "failed",
+			pp:   &PaymentProfile{PaymentType: PaymentTypeBankAccount, Disabled: true},
+			want: BankAccountFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pp.BankAccountStatus(); got != tt.want {
+				t.Errorf("PaymentProfile.BankAccountStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
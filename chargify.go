@@ -72,3 +72,9 @@ func selfServiceKey() (string, error) {
 	}
 	return strings.TrimSpace(string(b)), nil
 }
+
+// SiteSharedKey returns the site shared key from CHARGIFY_SITE_SHARED_KEY, used to sign self-service links and webhooks.
+// An empty key is returned when the env is not set.
+func SiteSharedKey() (string, error) {
+	return selfServiceKey()
+}
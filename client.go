@@ -12,6 +12,8 @@ type Client interface {
 	Put([]byte, string) (*http.Response, error)
 	Delete([]byte, string) (*http.Response, error)
 	GenerateSelfServiceLink(string, int64) string
+	SelfServiceURL(string, int64) (string, error)
+	VerifySelfServiceToken(string, int64, string) bool
 }
 
 type client struct {
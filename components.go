@@ -47,6 +47,7 @@ type ComponentBody struct {
 	SubscriptionID    int64 `json:"subscription_id,omitempty"`
 	AllocatedQuantity int64 `json:"allocated_quantity, omitempty"`
 	PricePointID      int64 `json:"price_point_id,omitempty"`
+	UnitBalance       int64 `json:"unit_balance,omitempty"`
 }
 
 type Price struct {
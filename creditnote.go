@@ -0,0 +1,232 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+)
+
+type CreditNote struct {
+	UID             string                   `json:"uid,omitempty"`
+	SiteID          int64                    `json:"site_id,omitempty"`
+	CustomerID      int64                    `json:"customer_id,omitempty"`
+	SubscriptionID  int64                    `json:"subscription_id,omitempty"`
+	Number          string                   `json:"number,omitempty"`
+	SequenceNumber  int64                    `json:"sequence_number,omitempty"`
+	IssueDate       string                   `json:"issue_date,omitempty"`
+	AppliedDate     string                   `json:"applied_date,omitempty"`
+	Status          string                   `json:"status,omitempty"`
+	Currency        string                   `json:"currency,omitempty"`
+	Memo            string                   `json:"memo,omitempty"`
+	SubtotalAmount  string                   `json:"subtotal_amount,omitempty"`
+	DiscountAmount  string                   `json:"discount_amount,omitempty"`
+	TaxAmount       string                   `json:"tax_amount,omitempty"`
+	TotalAmount     string                   `json:"total_amount,omitempty"`
+	AppliedAmount   string                   `json:"applied_amount,omitempty"`
+	RemainingAmount string                   `json:"remaining_amount,omitempty"`
+	LineItems       []*InvoiceLineItem       `json:"line_items,omitempty"`
+	Applications    []*CreditNoteApplication `json:"applications,omitempty"`
+	Refunds         []*InvoiceRefund         `json:"refunds,omitempty"`
+	OriginInvoices  []*OriginInvoice         `json:"origin_invoices,omitempty"`
+}
+
+type CreditNoteApplication struct {
+	UID             string `json:"uid,omitempty"`
+	TransactionTime string `json:"transaction_time,omitempty"`
+	InvoiceUID      string `json:"invoice_uid,omitempty"`
+	Memo            string `json:"memo,omitempty"`
+	AppliedAmount   string `json:"applied_amount,omitempty"`
+}
+
+type InvoiceRefund struct {
+	TransactionID  int64  `json:"transaction_id,omitempty"`
+	PaymentID      int64  `json:"payment_id,omitempty"`
+	Memo           string `json:"memo,omitempty"`
+	OriginalAmount string `json:"original_amount,omitempty"`
+	AppliedAmount  string `json:"applied_amount,omitempty"`
+}
+
+type OriginInvoice struct {
+	UID    string `json:"uid,omitempty"`
+	Number string `json:"number,omitempty"`
+	Date   string `json:"date,omitempty"`
+}
+
+type RefundRequest struct {
+	// Amount is a decimal string in the invoice currency, e.g. "10.50"
+	Amount      string `json:"amount"`
+	Memo        string `json:"memo"`
+	PaymentID   int64  `json:"payment_id"`
+	External    bool   `json:"external,omitempty"`
+	ApplyCredit bool   `json:"apply_credit,omitempty"`
+	VoidInvoice bool   `json:"void_invoice,omitempty"`
+}
+
+// Get the credit notes of a subscription, newest first, including their line items and applications.
+func GetCreditNotes(client Client, subscriptionID int64, pageNumber int32, perPage int32) (creditNotes []*CreditNote, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("credit_notes.json?subscription_id=%d&line_items=true&applications=true&refunds=true&origin_invoices=true&direction=desc&per_page=%d&page=%d", subscriptionID, perPage, pageNumber)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nestedCreditNotes := new(struct {
+		CreditNotes []*CreditNote `json:"credit_notes"`
+	})
+	if err = json.Unmarshal(body, nestedCreditNotes); err != nil {
+		return
+	}
+	return nestedCreditNotes.CreditNotes, nil
+}
+
+func GetCreditNote(client Client, uid string) (creditNote *CreditNote, err error) {
+	if uid == "" {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("credit_notes/%s.json", uid)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	creditNote = new(CreditNote)
+	err = json.Unmarshal(body, creditNote)
+	return
+}
+
+// Download the PDF of a credit note. The caller must close the returned reader.
+func DownloadCreditNote(client Client, uid string) (io.ReadCloser, error) {
+	if uid == "" {
+		return nil, NoID()
+	}
+	return getPDF(client, fmt.Sprintf("credit_notes/%s.pdf", uid))
+}
+
+// CreditNoteLookupError is returned by RefundInvoice when the refund went through but its credit note
+// could not be read. The refund must not be retried.
+type CreditNoteLookupError struct {
+	InvoiceUID string
+	Err        error
+}
+
+func (e *CreditNoteLookupError) Error() string {
+	return fmt.Sprintf("invoice %s was refunded but its credit note could not be found: %v", e.InvoiceUID, e.Err)
+}
+
+func (e *CreditNoteLookupError) Unwrap() error {
+	return e.Err
+}
+
+// Refund a paid invoice and return the refunded invoice with the credit note chargify generated for it.
+// The invoice is returned along with a *CreditNoteLookupError when only reading the credit note failed
+// or none of the invoice's credit notes matches the refund.
+func RefundInvoice(client Client, invoiceUID string, refund *RefundRequest) (invoice *Invoice, creditNote *CreditNote, err error) {
+	if invoiceUID == "" {
+		return nil, nil, NoID()
+	}
+	if refund == nil {
+		return nil, nil, errors.New("missing request")
+	}
+	if refund.Amount == "" || refund.Memo == "" || refund.PaymentID == 0 {
+		return nil, nil, errors.New("refunds need an amount, memo and payment id")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		Refund *RefundRequest `json:"refund"`
+	}{
+		Refund: refund,
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("invoices/%s/refunds.json", invoiceUID)
+	var res *http.Response
+	res, err = client.Post(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	invoice = new(Invoice)
+	if err = json.Unmarshal(body, invoice); err != nil {
+		return nil, nil, err
+	}
+	// the refund response is the invoice, the credit note of this refund is among its credits, most likely the newest
+	for i := len(invoice.Credits) - 1; i >= 0; i-- {
+		if invoice.Credits[i] == nil || invoice.Credits[i].CreditNoteUID == "" {
+			continue
+		}
+		creditNote, err = GetCreditNote(client, invoice.Credits[i].CreditNoteUID)
+		if err != nil {
+			return invoice, nil, &CreditNoteLookupError{InvoiceUID: invoiceUID, Err: err}
+		}
+		if creditNote.matchesRefund(invoiceUID, refund) {
+			return invoice, creditNote, nil
+		}
+	}
+	return invoice, nil, &CreditNoteLookupError{InvoiceUID: invoiceUID, Err: errors.New("no credit note matches the refund")}
+}
+
+// a credit note matches a refund when it lists a refund of the same payment and amount,
+// or lists no refunds but was issued from the invoice for the refunded amount
+func (c *CreditNote) matchesRefund(invoiceUID string, refund *RefundRequest) bool {
+	if c == nil {
+		return false
+	}
+	for _, r := range c.Refunds {
+		if r != nil && r.PaymentID == refund.PaymentID && (sameAmount(r.OriginalAmount, refund.Amount) || sameAmount(r.AppliedAmount, refund.Amount)) {
+			return true
+		}
+	}
+	if len(c.Refunds) > 0 || !sameAmount(c.TotalAmount, refund.Amount) {
+		return false
+	}
+	for _, o := range c.OriginInvoices {
+		if o != nil && o.UID == invoiceUID {
+			return true
+		}
+	}
+	return false
+}
+
+// compares decimal amounts, "10.5" is the same amount as "10.50"
+func sameAmount(a string, b string) bool {
+	x, ok := new(big.Rat).SetString(a)
+	if !ok {
+		return false
+	}
+	y, ok := new(big.Rat).SetString(b)
+	return ok && x.Cmp(y) == 0
+}
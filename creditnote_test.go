@@ -0,0 +1,126 @@
+package chargify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestRefundInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	refund := &RefundRequest{
+		Amount:    "10.50",
+		Memo:      "duplicate charge",
+		PaymentID: 5,
+	}
+	refundBody := []byte(`{"refund":{"amount":"10.50","memo":"duplicate charge","payment_id":5}}`)
+	refunded := []byte(`{"uid":"inv_1","credits":[{"credit_note_uid":"cn_0"},{"credit_note_uid":"cn_1","applied_amount":"10.50"}]}`)
+	wantInvoice := &Invoice{
+		UID: "inv_1",
+		Credits: []*InvoiceCredit{
+			{CreditNoteUID: "cn_0"},
+			{CreditNoteUID: "cn_1", AppliedAmount: "10.50"},
+		},
+	}
+	refundedStub := func() {
+		client.EXPECT().Post(refundBody, "invoices/inv_1/refunds.json").Return(&http.Response{
+			StatusCode: 201,
+			Body:       ioutil.NopCloser(bytes.NewReader(refunded)),
+		}, nil)
+	}
+	creditNoteStub := func(uid string, creditNote string) {
+		client.EXPECT().Get(fmt.Sprintf("credit_notes/%s.json", uid)).Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(creditNote))),
+		}, nil)
+	}
+	tests := []struct {
+		name           string
+		stub           func()
+		wantCreditNote *CreditNote
+		wantLookupErr  bool
+	}{
+		{
+			name: "refunded",
+			stub: func() {
+				refundedStub()
+				creditNoteStub("cn_1", `{"uid":"cn_1","total_amount":"10.50","refunds":[{"payment_id":5,"original_amount":"10.5"}]}`)
+			},
+			wantCreditNote: &CreditNote{
+				UID:         "cn_1",
+				TotalAmount: "10.50",
+				Refunds:     []*InvoiceRefund{{PaymentID: 5, OriginalAmount: "10.5"}},
+			},
+		},
+		{
+			name: "matched by origin invoice",
+			stub: func() {
+				refundedStub()
+				creditNoteStub("cn_1", `{"uid":"cn_1","total_amount":"10.50","origin_invoices":[{"uid":"inv_1"}]}`)
+			},
+			wantCreditNote: &CreditNote{
+				UID:            "cn_1",
+				TotalAmount:    "10.50",
+				OriginInvoices: []*OriginInvoice{{UID: "inv_1"}},
+			},
+		},
+		{
+			// a concurrent refund added its credit note after this one
+			name: "older credit note matches",
+			stub: func() {
+				refundedStub()
+				creditNoteStub("cn_1", `{"uid":"cn_1","total_amount":"3.00","refunds":[{"payment_id":6,"original_amount":"3.00"}]}`)
+				creditNoteStub("cn_0", `{"uid":"cn_0","total_amount":"10.50","refunds":[{"payment_id":5,"original_amount":"10.50"}]}`)
+			},
+			wantCreditNote: &CreditNote{
+				UID:         "cn_0",
+				TotalAmount: "10.50",
+				Refunds:     []*InvoiceRefund{{PaymentID: 5, OriginalAmount: "10.50"}},
+			},
+		},
+		{
+			name: "no credit note matches",
+			stub: func() {
+				refundedStub()
+				creditNoteStub("cn_1", `{"uid":"cn_1","total_amount":"3.00","refunds":[{"payment_id":6,"original_amount":"3.00"}]}`)
+				creditNoteStub("cn_0", `{"uid":"cn_0","total_amount":"10.50","origin_invoices":[{"uid":"inv_2"}]}`)
+			},
+			wantLookupErr: true,
+		},
+		{
+			name: "credit note lookup fails",
+			stub: func() {
+				refundedStub()
+				client.EXPECT().Get("credit_notes/cn_1.json").Return(&http.Response{
+					StatusCode: 404,
+					Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+				}, nil)
+			},
+			wantLookupErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.stub()
+			gotInvoice, gotCreditNote, err := RefundInvoice(client, "inv_1", refund)
+			var lookupErr *CreditNoteLookupError
+			if errors.As(err, &lookupErr) != tt.wantLookupErr || (err != nil && !tt.wantLookupErr) {
+				t.Fatalf("RefundInvoice() error = %v, wantLookupErr %v", err, tt.wantLookupErr)
+			}
+			if !reflect.DeepEqual(gotInvoice, wantInvoice) {
+				t.Errorf("RefundInvoice() invoice = %v, want %v", gotInvoice, wantInvoice)
+			}
+			if !reflect.DeepEqual(gotCreditNote, tt.wantCreditNote) {
+				t.Errorf("RefundInvoice() credit note = %v, want %v", gotCreditNote, tt.wantCreditNote)
+			}
+		})
+	}
+}
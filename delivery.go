@@ -0,0 +1,73 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// InvoiceDelivery lists who an invoice email is sent to.
+type InvoiceDelivery struct {
+	RecipientEmails    []string `json:"recipient_emails,omitempty"`
+	CCRecipientEmails  []string `json:"cc_recipient_emails,omitempty"`
+	BCCRecipientEmails []string `json:"bcc_recipient_emails,omitempty"`
+}
+
+// Send an invoice email again to the recipients in delivery.
+// Chargify only resends invoices, legacy statements have no equivalent in the API.
+func ResendInvoice(client Client, uid string, delivery *InvoiceDelivery) (err error) {
+	if uid == "" {
+		return NoID()
+	}
+	if delivery == nil {
+		return errors.New("missing request")
+	}
+	if len(delivery.RecipientEmails) == 0 {
+		return errors.New("no recipients provided")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(delivery)
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("invoices/%s/deliveries.json", uid)
+	var res *http.Response
+	res, err = client.Post(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	return checkError(res)
+}
+
+// Send an invoice email again to its customer, copying the customer's CC emails.
+func ResendInvoiceToCustomer(client Client, uid string) error {
+	invoice, err := GetInvoice(client, uid)
+	if err != nil {
+		return err
+	}
+	customer, err := GetCustomer(client, invoice.CustomerID)
+	if err != nil {
+		return err
+	}
+	if customer.Customer == nil {
+		return NotFound
+	}
+	return ResendInvoice(client, uid, customer.Customer.invoiceDelivery())
+}
+
+// CCEmails is a comma separated list
+func (c *CustomerBody) invoiceDelivery() *InvoiceDelivery {
+	delivery := new(InvoiceDelivery)
+	if c.Email != "" {
+		delivery.RecipientEmails = []string{c.Email}
+	}
+	for _, email := range strings.Split(c.CCEmails, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			delivery.CCRecipientEmails = append(delivery.CCRecipientEmails, email)
+		}
+	}
+	return delivery
+}
@@ -0,0 +1,33 @@
+package chargify
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestResendInvoiceToCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().Get("invoices/inv_1.json").Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"uid":"inv_1","customer_id":2}`))),
+		}, nil),
+		client.EXPECT().Get("customers/2.json").Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"customer":{"id":2,"email":"jane@example.com","cc_emails":"billing@example.com, ,ap@example.com"}}`))),
+		}, nil),
+		client.EXPECT().Post([]byte(`{"recipient_emails":["jane@example.com"],"cc_recipient_emails":["billing@example.com","ap@example.com"]}`), "invoices/inv_1/deliveries.json").Return(&http.Response{
+			StatusCode: 204,
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		}, nil),
+	)
+	if err := ResendInvoiceToCustomer(client, "inv_1"); err != nil {
+		t.Errorf("ResendInvoiceToCustomer() error = %v", err)
+	}
+}
@@ -1 +1,285 @@
 package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type Endpoint struct {
+	Endpoint *EndpointBody `json:"endpoint"`
+}
+
+// On create and update WebhookSubscriptions replaces the endpoint's events, an empty list unsubscribes from all of them.
+type EndpointBody struct {
+	ID                   int64    `json:"id,omitempty"`
+	URL                  string   `json:"url,omitempty"`
+	SiteID               int64    `json:"site_id,omitempty"`
+	Status               string   `json:"status,omitempty"`
+	WebhookSubscriptions []string `json:"webhook_subscriptions"`
+}
+
+type Webhook struct {
+	Webhook *WebhookBody `json:"webhook"`
+}
+
+type WebhookBody struct {
+	ID                  int64  `json:"id,omitempty"`
+	Event               string `json:"event,omitempty"`
+	CreatedAt           string `json:"created_at,omitempty"`
+	AcceptedAt          string `json:"accepted_at,omitempty"`
+	LastSentAt          string `json:"last_sent_at,omitempty"`
+	LastSentURL         string `json:"last_sent_url,omitempty"`
+	LastError           string `json:"last_error,omitempty"`
+	LastErrorAt         string `json:"last_error_at,omitempty"`
+	Successful          bool   `json:"successful,omitempty"`
+	Body                string `json:"body,omitempty"`
+	Signature           string `json:"signature,omitempty"`
+	SignatureHmacSha256 string `json:"signature_hmac_sha_256,omitempty"`
+}
+
+type WebhookFilter struct {
+	// Status is one of successful, failed, pending or paused
+	Status    string
+	SinceDate string
+	UntilDate string
+	Page      int32
+	PerPage   int32
+}
+
+var errNoWebhookSubscriptions = errors.New("no webhook subscriptions provided, use an empty list for none")
+
+// The list is not wrapped per endpoint like create and update.
+func GetEndpoints(client Client) (endpoints []*EndpointBody, err error) {
+	var res *http.Response
+	res, err = client.Get("endpoints.json")
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &endpoints)
+	return
+}
+
+func CreateEndpoint(client Client, endpoint *Endpoint) (response *Endpoint, err error) {
+	if endpoint == nil || endpoint.Endpoint == nil {
+		return nil, errors.New("missing request")
+	}
+	if endpoint.Endpoint.URL == "" {
+		return nil, errors.New("no url provided")
+	}
+	if endpoint.Endpoint.WebhookSubscriptions == nil {
+		return nil, errNoWebhookSubscriptions
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(endpoint)
+	if err != nil {
+		return
+	}
+	var res *http.Response
+	res, err = client.Post(jsonReq, "endpoints.json")
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(Endpoint)
+	err = json.Unmarshal(body, response)
+	return
+}
+
+func UpdateEndpoint(client Client, endpointID int64, endpoint *Endpoint) (response *Endpoint, err error) {
+	if endpointID == 0 {
+		return nil, NoID()
+	}
+	if endpoint == nil || endpoint.Endpoint == nil {
+		return nil, errors.New("missing request")
+	}
+	// a missing list would be sent as null and clear the endpoint's events
+	if endpoint.Endpoint.WebhookSubscriptions == nil {
+		return nil, errNoWebhookSubscriptions
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(endpoint)
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("endpoints/%d.json", endpointID)
+	var res *http.Response
+	res, err = client.Put(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(Endpoint)
+	err = json.Unmarshal(body, response)
+	return
+}
+
+func DeleteEndpoint(client Client, endpointID int64) (err error) {
+	if endpointID == 0 {
+		return NoID()
+	}
+	uri := fmt.Sprintf("endpoints/%d.json", endpointID)
+	var res *http.Response
+	res, err = client.Delete(nil, uri)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	return checkError(res)
+}
+
+// Subscribe an endpoint to additional webhook events, keeping the ones it already has.
+func EnableWebhookSubscriptions(client Client, endpointID int64, events ...string) (*Endpoint, error) {
+	return changeWebhookSubscriptions(client, endpointID, events, true)
+}
+
+// Unsubscribe an endpoint from webhook events, keeping the rest.
+func DisableWebhookSubscriptions(client Client, endpointID int64, events ...string) (*Endpoint, error) {
+	return changeWebhookSubscriptions(client, endpointID, events, false)
+}
+
+// chargify only accepts the full list of subscriptions, so read the endpoint and send back the merged list
+func changeWebhookSubscriptions(client Client, endpointID int64, events []string, enable bool) (*Endpoint, error) {
+	if endpointID == 0 {
+		return nil, NoID()
+	}
+	if len(events) == 0 {
+		return nil, errors.New("no events provided")
+	}
+	endpoints, err := GetEndpoints(client)
+	if err != nil {
+		return nil, err
+	}
+	var current *EndpointBody
+	for _, e := range endpoints {
+		if e != nil && e.ID == endpointID {
+			current = e
+			break
+		}
+	}
+	if current == nil {
+		return nil, NotFound
+	}
+	changed := make(map[string]bool)
+	for _, event := range events {
+		changed[event] = true
+	}
+	subscriptions := make([]string, 0, len(current.WebhookSubscriptions)+len(events))
+	for _, event := range current.WebhookSubscriptions {
+		if changed[event] {
+			if !enable {
+				continue
+			}
+			// already subscribed, don't add it twice
+			delete(changed, event)
+		}
+		subscriptions = append(subscriptions, event)
+	}
+	if enable {
+		for _, event := range events {
+			if changed[event] {
+				subscriptions = append(subscriptions, event)
+				delete(changed, event)
+			}
+		}
+	}
+	return UpdateEndpoint(client, endpointID, &Endpoint{
+		Endpoint: &EndpointBody{
+			URL:                  current.URL,
+			WebhookSubscriptions: subscriptions,
+		},
+	})
+}
+
+func GetWebhooks(client Client, filter *WebhookFilter) (webhooks []*Webhook, err error) {
+	query := url.Values{}
+	if filter != nil {
+		if filter.Status != "" {
+			query.Set("status", filter.Status)
+		}
+		if filter.SinceDate != "" {
+			query.Set("since_date", filter.SinceDate)
+		}
+		if filter.UntilDate != "" {
+			query.Set("until_date", filter.UntilDate)
+		}
+		if filter.Page != 0 {
+			query.Set("page", strconv.Itoa(int(filter.Page)))
+		}
+		if filter.PerPage != 0 {
+			query.Set("per_page", strconv.Itoa(int(filter.PerPage)))
+		}
+	}
+	uri := "webhooks.json"
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &webhooks)
+	return
+}
+
+// Ask chargify to send the given webhooks again.
+func ReplayWebhooks(client Client, webhookIDs ...int64) (err error) {
+	if len(webhookIDs) == 0 {
+		return NoID()
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		IDs []int64 `json:"ids"`
+	}{
+		IDs: webhookIDs,
+	})
+	if err != nil {
+		return
+	}
+	var res *http.Response
+	res, err = client.Post(jsonReq, "webhooks/replay.json")
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	return checkError(res)
+}
@@ -0,0 +1,205 @@
+package chargify
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestEnableWebhookSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("endpoints.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"id":1,"url":"https://example.com/hooks","webhook_subscriptions":["signup_success","payment_failure"]}]`))),
+	}, nil)
+	want := []byte(`{"endpoint":{"url":"https://example.com/hooks","webhook_subscriptions":["signup_success","payment_failure","renewal_success"]}}`)
+	client.EXPECT().Put(want, "endpoints/1.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader(want)),
+	}, nil)
+	got, err := EnableWebhookSubscriptions(client, 1, "renewal_success", "payment_failure")
+	if err != nil {
+		t.Fatalf("EnableWebhookSubscriptions() error = %v", err)
+	}
+	wantSubscriptions := []string{"signup_success", "payment_failure", "renewal_success"}
+	if !reflect.DeepEqual(got.Endpoint.WebhookSubscriptions, wantSubscriptions) {
+		t.Errorf("EnableWebhookSubscriptions() = %v, want %v", got.Endpoint.WebhookSubscriptions, wantSubscriptions)
+	}
+}
+
+func TestDisableWebhookSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("endpoints.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"id":1,"url":"https://example.com/hooks","webhook_subscriptions":["signup_success","payment_failure"]}]`))),
+	}, nil)
+	want := []byte(`{"endpoint":{"url":"https://example.com/hooks","webhook_subscriptions":["signup_success"]}}`)
+	client.EXPECT().Put(want, "endpoints/1.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader(want)),
+	}, nil)
+	if _, err := DisableWebhookSubscriptions(client, 1, "payment_failure"); err != nil {
+		t.Fatalf("DisableWebhookSubscriptions() error = %v", err)
+	}
+	if _, err := DisableWebhookSubscriptions(client, 2); !reflect.DeepEqual(err, errors.New("no events provided")) {
+		t.Errorf("DisableWebhookSubscriptions() error = %v, wantErr %v", err, errors.New("no events provided"))
+	}
+}
+
+func TestCreateEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	tests := []struct {
+		name         string
+		endpoint     *Endpoint
+		stub         func()
+		wantResponse *Endpoint
+		wantErr      error
+	}{
+		{
+			name: "create",
+			endpoint: &Endpoint{Endpoint: &EndpointBody{
+				URL:                  "https://example.com/hooks",
+				WebhookSubscriptions: []string{"signup_success"},
+			}},
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"endpoint":{"url":"https://example.com/hooks","webhook_subscriptions":["signup_success"]}}`), "endpoints.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"endpoint":{"id":1,"url":"https://example.com/hooks","status":"enabled","webhook_subscriptions":["signup_success"]}}`))),
+				}, nil)
+			},
+			wantResponse: &Endpoint{Endpoint: &EndpointBody{
+				ID:                   1,
+				URL:                  "https://example.com/hooks",
+				Status:               "enabled",
+				WebhookSubscriptions: []string{"signup_success"},
+			}},
+		},
+		{
+			name:    "nil endpoint",
+			wantErr: errors.New("missing request"),
+		},
+		{
+			name:     "no url",
+			endpoint: &Endpoint{Endpoint: &EndpointBody{WebhookSubscriptions: []string{}}},
+			wantErr:  errors.New("no url provided"),
+		},
+		{
+			name:     "no subscriptions",
+			endpoint: &Endpoint{Endpoint: &EndpointBody{URL: "https://example.com/hooks"}},
+			wantErr:  errNoWebhookSubscriptions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := CreateEndpoint(client, tt.endpoint)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("CreateEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("CreateEndpoint() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestUpdateEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	// an empty list is sent as [] to unsubscribe from every event
+	client.EXPECT().Put([]byte(`{"endpoint":{"url":"https://example.com/new","webhook_subscriptions":[]}}`), "endpoints/1.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"endpoint":{"id":1,"url":"https://example.com/new","webhook_subscriptions":[]}}`))),
+	}, nil)
+	got, err := UpdateEndpoint(client, 1, &Endpoint{Endpoint: &EndpointBody{
+		URL:                  "https://example.com/new",
+		WebhookSubscriptions: []string{},
+	}})
+	if err != nil {
+		t.Fatalf("UpdateEndpoint() error = %v", err)
+	}
+	want := &Endpoint{Endpoint: &EndpointBody{
+		ID:                   1,
+		URL:                  "https://example.com/new",
+		WebhookSubscriptions: []string{},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UpdateEndpoint() = %v, want %v", got, want)
+	}
+	if _, err = UpdateEndpoint(client, 1, &Endpoint{Endpoint: &EndpointBody{URL: "https://example.com/new"}}); err != errNoWebhookSubscriptions {
+		t.Errorf("UpdateEndpoint() error = %v, wantErr %v", err, errNoWebhookSubscriptions)
+	}
+	if _, err = UpdateEndpoint(client, 1, nil); !reflect.DeepEqual(err, errors.New("missing request")) {
+		t.Errorf("UpdateEndpoint() error = %v, wantErr missing request", err)
+	}
+}
+
+func TestGetEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("endpoints.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"id":1,"url":"https://example.com/hooks","webhook_subscriptions":["signup_success"]}]`))),
+	}, nil)
+	client.EXPECT().Delete(nil, "endpoints/1.json").Return(&http.Response{
+		StatusCode: 204,
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}, nil)
+	want := []*EndpointBody{{
+		ID:                   1,
+		URL:                  "https://example.com/hooks",
+		WebhookSubscriptions: []string{"signup_success"},
+	}}
+	got, err := GetEndpoints(client)
+	if err != nil {
+		t.Fatalf("GetEndpoints() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetEndpoints() = %v, want %v", got, want)
+	}
+	if err = DeleteEndpoint(client, 1); err != nil {
+		t.Errorf("DeleteEndpoint() error = %v", err)
+	}
+}
+
+func TestReplayWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("webhooks.json?page=2&per_page=50&status=failed").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"webhook":{"id":7,"event":"signup_success","last_error":"timeout"}},{"webhook":{"id":8,"event":"payment_failure"}}]`))),
+	}, nil)
+	client.EXPECT().Post([]byte(`{"ids":[7,8]}`), "webhooks/replay.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"status":"ok"}`))),
+	}, nil)
+	webhooks, err := GetWebhooks(client, &WebhookFilter{Status: "failed", Page: 2, PerPage: 50})
+	if err != nil {
+		t.Fatalf("GetWebhooks() error = %v", err)
+	}
+	var ids []int64
+	for _, w := range webhooks {
+		ids = append(ids, w.Webhook.ID)
+	}
+	if !reflect.DeepEqual(ids, []int64{7, 8}) {
+		t.Errorf("GetWebhooks() ids = %v, want [7 8]", ids)
+	}
+	if err = ReplayWebhooks(client, ids...); err != nil {
+		t.Errorf("ReplayWebhooks() error = %v", err)
+	}
+	if err = ReplayWebhooks(client); !reflect.DeepEqual(err, NoID()) {
+		t.Errorf("ReplayWebhooks() error = %v, wantErr %v", err, NoID())
+	}
+}
@@ -3,6 +3,7 @@ package chargify
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
@@ -17,6 +18,8 @@ type Error struct {
 var (
 	NotFound     = errors.New("not found")
 	Unrecognized = errors.New("unrecognized response code")
+	// UnknownReasonCode is wrapped by cancellations whose reason code is not set up on the site
+	UnknownReasonCode = errors.New("unknown reason code")
 )
 
 func checkError(res *http.Response) error {
@@ -72,3 +75,14 @@ func extractErr(body io.ReadCloser) *Error {
 func NoID() error {
 	return errors.New("no ID provided")
 }
+
+// StateError is returned when an action is not allowed for a subscription's current state.
+type StateError struct {
+	SubscriptionID int64
+	State          string
+	Action         string
+}
+
+func (e *StateError) Error() string {
+	return fmt.Sprintf("cannot %s subscription %d in state %s", e.Action, e.SubscriptionID, e.State)
+}
@@ -0,0 +1,217 @@
+package chargify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	maxEventsPerPage = 200
+	// used by EventPoller when Interval is not positive
+	defaultEventPollInterval = time.Minute
+)
+
+type EventFilter struct {
+	SinceID int64
+	MaxID   int64
+	// Keys limits the events to these keys, e.g. signup_success
+	Keys []string
+	// DateField is created_at or updated_at and applies to StartDate and EndDate
+	DateField string
+	StartDate string
+	EndDate   string
+	// Direction is asc or desc, chargify defaults to desc
+	Direction string
+	Page      int32
+	PerPage   int32
+}
+
+func (f *EventFilter) query() string {
+	if f == nil {
+		return ""
+	}
+	query := url.Values{}
+	if f.SinceID != 0 {
+		query.Set("since_id", strconv.FormatInt(f.SinceID, 10))
+	}
+	if f.MaxID != 0 {
+		query.Set("max_id", strconv.FormatInt(f.MaxID, 10))
+	}
+	if len(f.Keys) > 0 {
+		query.Set("filter", strings.Join(f.Keys, ","))
+	}
+	if f.DateField != "" {
+		query.Set("date_field", f.DateField)
+	}
+	if f.StartDate != "" {
+		query.Set("start_date", f.StartDate)
+	}
+	if f.EndDate != "" {
+		query.Set("end_date", f.EndDate)
+	}
+	if f.Direction != "" {
+		query.Set("direction", f.Direction)
+	}
+	if f.Page != 0 {
+		query.Set("page", strconv.Itoa(int(f.Page)))
+	}
+	if f.PerPage != 0 {
+		query.Set("per_page", strconv.Itoa(int(f.PerPage)))
+	}
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
+
+// Get the events of the whole site.
+func GetEvents(client Client, filter *EventFilter) ([]*Event, error) {
+	return getEvents(client, "events.json"+filter.query())
+}
+
+// Get the events of a single subscription.
+func GetSubscriptionEvents(client Client, subscriptionID int64, filter *EventFilter) ([]*Event, error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	return getEvents(client, fmt.Sprintf("subscriptions/%d/events.json%s", subscriptionID, filter.query()))
+}
+
+func getEvents(client Client, uri string) ([]*Event, error) {
+	res, err := client.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkError(res); err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var nestedEvents []*Events
+	if err = json.Unmarshal(body, &nestedEvents); err != nil {
+		return nil, err
+	}
+	var events []*Event
+	for _, e := range nestedEvents {
+		events = append(events, e.Event)
+	}
+	return events, nil
+}
+
+// EventPoller streams new events by repeatedly asking for everything after the last event it has seen.
+// It is meant as a fallback for missed webhooks, persist LastID to resume where a previous poller stopped.
+type EventPoller struct {
+	client Client
+	// SubscriptionID limits the poller to one subscription, 0 polls the whole site
+	SubscriptionID int64
+	Keys           []string
+	// Interval between polls, defaults to a minute when not positive
+	Interval time.Duration
+	// OnError is called when a poll fails, the poller keeps going afterwards. Errors are logged when nil.
+	OnError func(error)
+	mu      sync.Mutex
+	lastID  int64
+}
+
+func NewEventPoller(client Client, lastID int64, interval time.Duration) *EventPoller {
+	return &EventPoller{
+		client:   client,
+		Interval: interval,
+		lastID:   lastID,
+	}
+}
+
+// LastID is the id of the last event delivered, it is safe to call while the poller is running.
+func (p *EventPoller) LastID() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastID
+}
+
+func (p *EventPoller) setLastID(id int64) {
+	p.mu.Lock()
+	p.lastID = id
+	p.mu.Unlock()
+}
+
+// Start polls until ctx is done, sending events in the order they happened. The channel is closed when polling stops.
+// The poller must not be modified once started.
+func (p *EventPoller) Start(ctx context.Context) <-chan *Event {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = defaultEventPollInterval
+	}
+	events := make(chan *Event)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := p.poll(ctx, events); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if p.OnError != nil {
+					p.OnError(err)
+				} else {
+					log.Printf("polling events: %v", err)
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events
+}
+
+// fetches every page after LastID, advancing LastID as each event is delivered
+func (p *EventPoller) poll(ctx context.Context, events chan<- *Event) error {
+	lastID := p.LastID()
+	for {
+		filter := &EventFilter{
+			SinceID:   lastID,
+			Keys:      p.Keys,
+			Direction: "asc",
+			PerPage:   maxEventsPerPage,
+		}
+		var page []*Event
+		var err error
+		if p.SubscriptionID != 0 {
+			page, err = GetSubscriptionEvents(p.client, p.SubscriptionID, filter)
+		} else {
+			page, err = GetEvents(p.client, filter)
+		}
+		if err != nil {
+			return err
+		}
+		for _, e := range page {
+			if e == nil || e.Id <= lastID {
+				continue
+			}
+			select {
+			case events <- e:
+				lastID = e.Id
+				p.setLastID(lastID)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if len(page) < maxEventsPerPage || lastID <= filter.SinceID {
+			return nil
+		}
+	}
+}
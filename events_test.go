@@ -0,0 +1,106 @@
+package chargify
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestEventFilter_query(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *EventFilter
+		want   string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "all",
+			filter: &EventFilter{
+				SinceID:   10,
+				MaxID:     20,
+				Keys:      []string{"signup_success", "payment_failure"},
+				DateField: "created_at",
+				StartDate: "2021-01-01",
+				EndDate:   "2021-02-01",
+				Direction: "asc",
+				PerPage:   50,
+			},
+			want: "?date_field=created_at&direction=asc&end_date=2021-02-01&filter=signup_success%2Cpayment_failure&max_id=20&per_page=50&since_id=10&start_date=2021-01-01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.query(); got != tt.want {
+				t.Errorf("EventFilter.query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventPoller_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("events.json?direction=asc&per_page=200&since_id=5").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"event":{"id":6,"key":"signup_success"}},{"event":{"id":7,"key":"payment_success"}}]`))),
+	}, nil)
+	client.EXPECT().Get("events.json?direction=asc&per_page=200&since_id=7").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[]`))),
+	}, nil).AnyTimes()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	poller := NewEventPoller(client, 5, time.Millisecond)
+	events := poller.Start(ctx)
+	for _, want := range []int64{6, 7} {
+		select {
+		case e := <-events:
+			if e.Id != want {
+				t.Errorf("EventPoller.Start() event = %d, want %d", e.Id, want)
+			}
+			if id := poller.LastID(); id < want-1 || id > want {
+				t.Errorf("EventPoller.LastID() = %d while running, want %d or %d", id, want-1, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("EventPoller.Start() timed out")
+		}
+	}
+	cancel()
+	for range events {
+	}
+	if id := poller.LastID(); id != 7 {
+		t.Errorf("EventPoller.LastID() = %d, want 7", id)
+	}
+}
+
+func TestEventPoller_StartDefaultInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("events.json?direction=asc&per_page=200").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"event":{"id":1,"key":"signup_success"}}]`))),
+	}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	poller := NewEventPoller(client, 0, 0)
+	events := poller.Start(ctx)
+	select {
+	case e := <-events:
+		if e.Id != 1 {
+			t.Errorf("EventPoller.Start() event = %d, want 1", e.Id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("EventPoller.Start() timed out")
+	}
+	cancel()
+	for range events {
+	}
+}
@@ -0,0 +1,206 @@
+package chargify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+type ExportType string
+
+const (
+	ExportProformaInvoices ExportType = "proforma_invoices"
+	ExportInvoices         ExportType = "invoices"
+	ExportSubscriptions    ExportType = "subscriptions"
+)
+
+const maxExportRowsPerPage = 1000
+
+// backoff between export status checks, doubled after every check until it reaches the max
+var (
+	exportPollStart = time.Second
+	exportPollMax   = time.Minute
+)
+
+// BatchJob is an export running in the background on chargify.
+type BatchJob struct {
+	ID         int64  `json:"id,omitempty"`
+	FinishedAt string `json:"finished_at,omitempty"`
+	RowCount   int64  `json:"row_count,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	Completed  string `json:"completed,omitempty"`
+}
+
+// Done reports whether the rows of the export can be read.
+func (j *BatchJob) Done() bool {
+	return j.FinishedAt != ""
+}
+
+// Start an export of every row of exportType. Rows are available once the job is done, see WaitForExport.
+func StartExport(client Client, exportType ExportType) (job *BatchJob, err error) {
+	uri := fmt.Sprintf("api_exports/%s.json", exportType)
+	var res *http.Response
+	res, err = client.Post(nil, uri)
+	if err != nil {
+		return
+	}
+	return readBatchJob(res)
+}
+
+func GetExport(client Client, exportType ExportType, batchID int64) (job *BatchJob, err error) {
+	if batchID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("api_exports/%s/%d.json", exportType, batchID)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	return readBatchJob(res)
+}
+
+func readBatchJob(res *http.Response) (job *BatchJob, err error) {
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nestedJob := new(struct {
+		BatchJob *BatchJob `json:"batchjob"`
+	})
+	if err = json.Unmarshal(body, nestedJob); err != nil {
+		return
+	}
+	return nestedJob.BatchJob, nil
+}
+
+// Wait until an export is done, checking its status with an increasing backoff. Stops early when ctx is done.
+func WaitForExport(ctx context.Context, client Client, exportType ExportType, batchID int64) (*BatchJob, error) {
+	wait := exportPollStart
+	for {
+		job, err := GetExport(client, exportType, batchID)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil && job.Done() {
+			return job, nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return job, ctx.Err()
+		case <-timer.C:
+		}
+		wait *= 2
+		if wait > exportPollMax {
+			wait = exportPollMax
+		}
+	}
+}
+
+func getExportRows(client Client, exportType ExportType, batchID int64, pageNumber int32, perPage int32, rows interface{}) (err error) {
+	if batchID == 0 {
+		return NoID()
+	}
+	uri := fmt.Sprintf("api_exports/%s/%d/rows.json?per_page=%d&page=%d", exportType, batchID, perPage, pageNumber)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	return json.Unmarshal(body, rows)
+}
+
+// calls page with 1, 2, ... until it returns less than a full page of rows
+func eachExportPage(page func(pageNumber int32) (int, error)) error {
+	for pageNumber := int32(1); ; pageNumber++ {
+		n, err := page(pageNumber)
+		if err != nil {
+			return err
+		}
+		if n < maxExportRowsPerPage {
+			return nil
+		}
+	}
+}
+
+func GetExportedProformaInvoices(client Client, batchID int64, pageNumber int32, perPage int32) (invoices []*ProformaInvoice, err error) {
+	err = getExportRows(client, ExportProformaInvoices, batchID, pageNumber, perPage, &invoices)
+	return
+}
+
+func GetExportedInvoices(client Client, batchID int64, pageNumber int32, perPage int32) (invoices []*Invoice, err error) {
+	err = getExportRows(client, ExportInvoices, batchID, pageNumber, perPage, &invoices)
+	return
+}
+
+func GetExportedSubscriptions(client Client, batchID int64, pageNumber int32, perPage int32) (subscriptions []*SubscriptionResponse, err error) {
+	err = getExportRows(client, ExportSubscriptions, batchID, pageNumber, perPage, &subscriptions)
+	return
+}
+
+// Call fn for every row of a finished proforma invoice export, stopping at the first error.
+func EachExportedProformaInvoice(client Client, batchID int64, fn func(*ProformaInvoice) error) error {
+	return eachExportPage(func(pageNumber int32) (int, error) {
+		invoices, err := GetExportedProformaInvoices(client, batchID, pageNumber, maxExportRowsPerPage)
+		if err != nil {
+			return 0, err
+		}
+		for _, invoice := range invoices {
+			if err = fn(invoice); err != nil {
+				return 0, err
+			}
+		}
+		return len(invoices), nil
+	})
+}
+
+// Call fn for every row of a finished invoice export, stopping at the first error.
+func EachExportedInvoice(client Client, batchID int64, fn func(*Invoice) error) error {
+	return eachExportPage(func(pageNumber int32) (int, error) {
+		invoices, err := GetExportedInvoices(client, batchID, pageNumber, maxExportRowsPerPage)
+		if err != nil {
+			return 0, err
+		}
+		for _, invoice := range invoices {
+			if err = fn(invoice); err != nil {
+				return 0, err
+			}
+		}
+		return len(invoices), nil
+	})
+}
+
+// Call fn for every row of a finished subscription export, stopping at the first error.
+func EachExportedSubscription(client Client, batchID int64, fn func(*SubscriptionResponse) error) error {
+	return eachExportPage(func(pageNumber int32) (int, error) {
+		subscriptions, err := GetExportedSubscriptions(client, batchID, pageNumber, maxExportRowsPerPage)
+		if err != nil {
+			return 0, err
+		}
+		for _, subscription := range subscriptions {
+			if err = fn(subscription); err != nil {
+				return 0, err
+			}
+		}
+		return len(subscriptions), nil
+	})
+}
@@ -0,0 +1,67 @@
+package chargify
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestWaitForExport(t *testing.T) {
+	exportPollStart, exportPollMax = time.Millisecond, 2*time.Millisecond
+	defer func() {
+		exportPollStart, exportPollMax = time.Second, time.Minute
+	}()
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().Get("api_exports/invoices/7.json").Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"batchjob":{"id":7,"created_at":"2021-01-01T00:00:00Z"}}`))),
+		}, nil),
+		client.EXPECT().Get("api_exports/invoices/7.json").Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"batchjob":{"id":7,"created_at":"2021-01-01T00:00:00Z","finished_at":"2021-01-01T00:01:00Z","row_count":2,"completed":"true"}}`))),
+		}, nil),
+	)
+	got, err := WaitForExport(context.Background(), client, ExportInvoices, 7)
+	if err != nil {
+		t.Fatalf("WaitForExport() error = %v", err)
+	}
+	want := &BatchJob{
+		ID:         7,
+		CreatedAt:  "2021-01-01T00:00:00Z",
+		FinishedAt: "2021-01-01T00:01:00Z",
+		RowCount:   2,
+		Completed:  "true",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WaitForExport() = %v, want %v", got, want)
+	}
+}
+
+func TestEachExportedInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("api_exports/invoices/7/rows.json?per_page=1000&page=1").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"uid":"inv_1"},{"uid":"inv_2"}]`))),
+	}, nil)
+	var got []string
+	err := EachExportedInvoice(client, 7, func(invoice *Invoice) error {
+		got = append(got, invoice.UID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachExportedInvoice() error = %v", err)
+	}
+	if want := []string{"inv_1", "inv_2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("EachExportedInvoice() rows = %v, want %v", got, want)
+	}
+}
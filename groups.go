@@ -0,0 +1,215 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+type SubscriptionGroup struct {
+	SubscriptionGroup *SubscriptionGroupBody `json:"subscription_group"`
+}
+
+type SubscriptionGroupBody struct {
+	UID                     string                `json:"uid,omitempty"`
+	Scheme                  int64                 `json:"scheme,omitempty"`
+	CustomerID              int64                 `json:"customer_id,omitempty"`
+	PaymentProfileID        int64                 `json:"payment_profile_id,omitempty"`
+	PaymentCollectionMethod string                `json:"payment_collection_method,omitempty"`
+	SubscriptionIDs         []int64               `json:"subscription_ids,omitempty"`
+	PrimarySubscriptionID   int64                 `json:"primary_subscription_id,omitempty"`
+	NextAssessmentAt        string                `json:"next_assessment_at,omitempty"`
+	State                   string                `json:"state,omitempty"`
+	CancelAtEndOfPeriod     bool                  `json:"cancel_at_end_of_period,omitempty"`
+	AccountBalances         *GroupAccountBalances `json:"account_balances,omitempty"`
+	CreatedAt               string                `json:"created_at,omitempty"`
+	// Request
+	SubscriptionID int64   `json:"subscription_id,omitempty"`
+	MemberIDs      []int64 `json:"member_ids,omitempty"`
+}
+
+type GroupAccountBalances struct {
+	Prepayments      *GroupBalance `json:"prepayments,omitempty"`
+	ServiceCredits   *GroupBalance `json:"service_credits,omitempty"`
+	OpenInvoices     *GroupBalance `json:"open_invoices,omitempty"`
+	PendingDiscounts *GroupBalance `json:"pending_discounts,omitempty"`
+}
+
+type GroupBalance struct {
+	BalanceInCents int64 `json:"balance_in_cents"`
+}
+
+type GroupMembership struct {
+	Target  *GroupTarget  `json:"target"`
+	Billing *GroupBilling `json:"billing,omitempty"`
+}
+
+type GroupTarget struct {
+	// Type is subscription, parent or self, chargify picks the group of the target
+	Type string `json:"type"`
+	ID   int64  `json:"id,omitempty"`
+}
+
+type GroupBilling struct {
+	Accrue    bool `json:"accrue,omitempty"`
+	AlignDate bool `json:"align_date,omitempty"`
+	Prorate   bool `json:"prorate,omitempty"`
+}
+
+// Create a group billed through primarySubscriptionID, with memberIDs as the other subscriptions in it.
+func CreateSubscriptionGroup(client Client, primarySubscriptionID int64, memberIDs ...int64) (response *SubscriptionGroup, err error) {
+	if primarySubscriptionID == 0 {
+		return nil, NoID()
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&SubscriptionGroup{
+		SubscriptionGroup: &SubscriptionGroupBody{
+			SubscriptionID: primarySubscriptionID,
+			MemberIDs:      memberIDs,
+		},
+	})
+	if err != nil {
+		return
+	}
+	var res *http.Response
+	res, err = client.Post(jsonReq, "subscription_groups.json")
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(SubscriptionGroup)
+	err = json.Unmarshal(body, response)
+	return
+}
+
+// Get a group, including its primary subscription and account balances.
+func GetSubscriptionGroup(client Client, uid string) (group *SubscriptionGroupBody, err error) {
+	if uid == "" {
+		return nil, NoID()
+	}
+	return getSubscriptionGroup(client, fmt.Sprintf("subscription_groups/%s.json", uid))
+}
+
+// Get the group a subscription belongs to.
+func GetSubscriptionGroupBySubscription(client Client, subscriptionID int64) (group *SubscriptionGroupBody, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	return getSubscriptionGroup(client, fmt.Sprintf("subscription_groups/lookup.json?subscription_id=%d", subscriptionID))
+}
+
+// the read endpoints return the group without the subscription_group wrapper
+func getSubscriptionGroup(client Client, uri string) (group *SubscriptionGroupBody, err error) {
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	group = new(SubscriptionGroupBody)
+	err = json.Unmarshal(body, group)
+	return
+}
+
+func GetGroupPrimarySubscription(client Client, uid string) (*SubscriptionResponse, error) {
+	group, err := GetSubscriptionGroup(client, uid)
+	if err != nil {
+		return nil, err
+	}
+	if group.PrimarySubscriptionID == 0 {
+		return nil, errors.New("group has no primary subscription")
+	}
+	return GetSubscription(client, group.PrimarySubscriptionID)
+}
+
+// Add an existing subscription to the group of primarySubscriptionID.
+func AddSubscriptionToGroup(client Client, subscriptionID int64, primarySubscriptionID int64, billing *GroupBilling) (response *SubscriptionGroup, err error) {
+	if subscriptionID == 0 || primarySubscriptionID == 0 {
+		return nil, NoID()
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		Group *GroupMembership `json:"group"`
+	}{
+		Group: &GroupMembership{
+			Target: &GroupTarget{
+				Type: "subscription",
+				ID:   primarySubscriptionID,
+			},
+			Billing: billing,
+		},
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d/group.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Post(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(SubscriptionGroup)
+	err = json.Unmarshal(body, response)
+	return
+}
+
+func RemoveSubscriptionFromGroup(client Client, subscriptionID int64) (err error) {
+	if subscriptionID == 0 {
+		return NoID()
+	}
+	uri := fmt.Sprintf("subscriptions/%d/group.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Delete(nil, uri)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	return checkError(res)
+}
+
+// Sign up a new subscription directly into the group of primarySubscriptionID.
+func (req *SubscriptionRequest) CreateInGroup(client Client, primarySubscriptionID int64, billing *GroupBilling) (*SubscriptionResponse, error) {
+	if primarySubscriptionID == 0 {
+		return nil, NoID()
+	}
+	if req.Request == nil {
+		return nil, errors.New("missing request")
+	}
+	// the caller's request is left without the group
+	create := *req.Request
+	create.Group = &GroupMembership{
+		Target: &GroupTarget{
+			Type: "subscription",
+			ID:   primarySubscriptionID,
+		},
+		Billing: billing,
+	}
+	return (&SubscriptionRequest{Request: &create}).Create(client)
+}
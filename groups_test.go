@@ -0,0 +1,123 @@
+package chargify
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCreateSubscriptionGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Post([]byte(`{"subscription_group":{"subscription_id":1,"member_ids":[2,3]}}`), "subscription_groups.json").Return(&http.Response{
+		StatusCode: 201,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"subscription_group":{"uid":"grp_1","customer_id":9,"subscription_ids":[1,2,3],"primary_subscription_id":1}}`))),
+	}, nil)
+	want := &SubscriptionGroup{
+		SubscriptionGroup: &SubscriptionGroupBody{
+			UID:                   "grp_1",
+			CustomerID:            9,
+			SubscriptionIDs:       []int64{1, 2, 3},
+			PrimarySubscriptionID: 1,
+		},
+	}
+	got, err := CreateSubscriptionGroup(client, 1, 2, 3)
+	if err != nil {
+		t.Fatalf("CreateSubscriptionGroup() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CreateSubscriptionGroup() = %v, want %v", got, want)
+	}
+	if _, err = CreateSubscriptionGroup(client, 0); !reflect.DeepEqual(err, NoID()) {
+		t.Errorf("CreateSubscriptionGroup() error = %v, want %v", err, NoID())
+	}
+}
+
+func TestGetSubscriptionGroupBySubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	// the read endpoints are not wrapped in subscription_group
+	client.EXPECT().Get("subscription_groups/lookup.json?subscription_id=2").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"uid":"grp_1","primary_subscription_id":1,"account_balances":{"open_invoices":{"balance_in_cents":500}}}`))),
+	}, nil)
+	want := &SubscriptionGroupBody{
+		UID:                   "grp_1",
+		PrimarySubscriptionID: 1,
+		AccountBalances: &GroupAccountBalances{
+			OpenInvoices: &GroupBalance{BalanceInCents: 500},
+		},
+	}
+	got, err := GetSubscriptionGroupBySubscription(client, 2)
+	if err != nil {
+		t.Fatalf("GetSubscriptionGroupBySubscription() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSubscriptionGroupBySubscription() = %v, want %v", got, want)
+	}
+}
+
+func TestAddSubscriptionToGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Post([]byte(`{"group":{"target":{"type":"subscription","id":1},"billing":{"prorate":true}}}`), "subscriptions/2/group.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"subscription_group":{"uid":"grp_1","subscription_ids":[1,2]}}`))),
+	}, nil)
+	client.EXPECT().Delete(nil, "subscriptions/2/group.json").Return(&http.Response{
+		StatusCode: 204,
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}, nil)
+	want := &SubscriptionGroup{
+		SubscriptionGroup: &SubscriptionGroupBody{
+			UID:             "grp_1",
+			SubscriptionIDs: []int64{1, 2},
+		},
+	}
+	got, err := AddSubscriptionToGroup(client, 2, 1, &GroupBilling{Prorate: true})
+	if err != nil {
+		t.Fatalf("AddSubscriptionToGroup() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AddSubscriptionToGroup() = %v, want %v", got, want)
+	}
+	if err = RemoveSubscriptionFromGroup(client, 2); err != nil {
+		t.Errorf("RemoveSubscriptionFromGroup() error = %v", err)
+	}
+}
+
+func TestSubscriptionRequest_CreateInGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	res := &SubscriptionResponse{ID: 2}
+	body, err := json.Marshal(res.wrap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.EXPECT().Post([]byte(`{"subscription":{"product_handle":"basic","customer_id":"9","group":{"target":{"type":"subscription","id":1}}}}`), "subscriptions.json").Return(&http.Response{
+		StatusCode: 201,
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	}, nil)
+	req := &SubscriptionRequest{
+		Request: &SubscriptionCreate{
+			ProductHandle: "basic",
+			CustomerID:    "9",
+		},
+	}
+	got, err := req.CreateInGroup(client, 1, nil)
+	if err != nil {
+		t.Fatalf("SubscriptionRequest.CreateInGroup() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, res) {
+		t.Errorf("SubscriptionRequest.CreateInGroup() = %v, want %v", got, res)
+	}
+	if req.Request.Group != nil {
+		t.Errorf("SubscriptionRequest.CreateInGroup() set Group on the caller's request")
+	}
+}
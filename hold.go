@@ -0,0 +1,121 @@
+package chargify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+type SubscriptionHold struct {
+	AutomaticallyResumeAt string `json:"automatically_resume_at,omitempty"`
+}
+
+type subscriptionHoldRequest struct {
+	Hold *SubscriptionHold `json:"hold"`
+}
+
+// chargify clears the automatic resume when automatically_resume_at is null
+type automaticResumeUpdate struct {
+	AutomaticallyResumeAt *string `json:"automatically_resume_at"`
+}
+
+// Put a subscription on hold. If automaticallyResumeAt is empty the subscription stays on hold until resumed.
+// The subscription is fetched first and a *StateError is returned if its state does not allow a hold.
+func HoldSubscription(client Client, subscriptionID int64, automaticallyResumeAt string) (response *SubscriptionResponse, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	var subscription *SubscriptionResponse
+	subscription, err = GetSubscription(client, subscriptionID)
+	if err != nil {
+		return
+	}
+	switch subscription.State {
+	case SubscriptionStateActive, SubscriptionStateTrialing, SubscriptionStatePastDue:
+	default:
+		return nil, &StateError{
+			SubscriptionID: subscriptionID,
+			State:          subscription.State,
+			Action:         "hold",
+		}
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&subscriptionHoldRequest{
+		Hold: &SubscriptionHold{
+			AutomaticallyResumeAt: automaticallyResumeAt,
+		},
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d/hold.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Post(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(SubscriptionResponse)
+	err = json.Unmarshal(body, response.wrap())
+	return
+}
+
+// Change or clear the date an on hold subscription automatically resumes.
+// Passing an empty automaticallyResumeAt removes the automatic resume.
+func UpdateAutomaticResume(client Client, subscriptionID int64, automaticallyResumeAt string) (response *SubscriptionResponse, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	var subscription *SubscriptionResponse
+	subscription, err = GetSubscription(client, subscriptionID)
+	if err != nil {
+		return
+	}
+	if subscription.State != SubscriptionStateOnHold {
+		return nil, &StateError{
+			SubscriptionID: subscriptionID,
+			State:          subscription.State,
+			Action:         "update automatic resume for",
+		}
+	}
+	update := new(automaticResumeUpdate)
+	if automaticallyResumeAt != "" {
+		update.AutomaticallyResumeAt = &automaticallyResumeAt
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		Hold *automaticResumeUpdate `json:"hold"`
+	}{
+		Hold: update,
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d/hold.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Put(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(SubscriptionResponse)
+	err = json.Unmarshal(body, response.wrap())
+	return
+}
@@ -0,0 +1,204 @@
+package chargify
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHoldSubscription(t *testing.T) {
+	type args struct {
+		subscriptionID        int64
+		automaticallyResumeAt string
+		stub                  func()
+	}
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	subscriptionBody := func(state string) []byte {
+		b, _ := json.Marshal((&SubscriptionResponse{
+			ID:    123456789,
+			State: state,
+		}).wrap())
+		return b
+	}
+	held := &SubscriptionResponse{
+		ID:                    123456789,
+		State:                 SubscriptionStateOnHold,
+		AutomaticallyResumeAt: "2021-02-01",
+	}
+	heldBody, err := json.Marshal(held.wrap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *SubscriptionResponse
+		wantErr      error
+	}{
+		{
+			name: "hold",
+			args: args{
+				subscriptionID:        123456789,
+				automaticallyResumeAt: "2021-02-01",
+				stub: func() {
+					client.EXPECT().Get("subscriptions/123456789.json").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader(subscriptionBody(SubscriptionStateActive))),
+					}, nil)
+					client.EXPECT().Post([]byte(`{"hold":{"automatically_resume_at":"2021-02-01"}}`), "subscriptions/123456789/hold.json").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader(heldBody)),
+					}, nil)
+				},
+			},
+			wantResponse: held,
+		},
+		{
+			name: "hold until resumed",
+			args: args{
+				subscriptionID: 123456789,
+				stub: func() {
+					client.EXPECT().Get("subscriptions/123456789.json").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader(subscriptionBody(SubscriptionStatePastDue))),
+					}, nil)
+					client.EXPECT().Post([]byte(`{"hold":{}}`), "subscriptions/123456789/hold.json").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader(heldBody)),
+					}, nil)
+				},
+			},
+			wantResponse: held,
+		},
+		{
+			name: "hold canceled",
+			args: args{
+				subscriptionID: 123456789,
+				stub: func() {
+					client.EXPECT().Get("subscriptions/123456789.json").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader(subscriptionBody(SubscriptionStateCanceled))),
+					}, nil)
+				},
+			},
+			wantErr: &StateError{
+				SubscriptionID: 123456789,
+				State:          SubscriptionStateCanceled,
+				Action:         "hold",
+			},
+		},
+		{
+			name:    "hold no id",
+			wantErr: NoID(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.args.stub != nil {
+				tt.args.stub()
+			}
+			gotResponse, err := HoldSubscription(client, tt.args.subscriptionID, tt.args.automaticallyResumeAt)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("HoldSubscription() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("HoldSubscription() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestUpdateAutomaticResume(t *testing.T) {
+	type args struct {
+		automaticallyResumeAt string
+		stub                  func()
+	}
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	subscriptionBody := func(state string) []byte {
+		b, _ := json.Marshal((&SubscriptionResponse{
+			ID:    123456789,
+			State: state,
+		}).wrap())
+		return b
+	}
+	getSubscription := func(state string) {
+		client.EXPECT().Get("subscriptions/123456789.json").Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader(subscriptionBody(state))),
+		}, nil)
+	}
+	held := &SubscriptionResponse{
+		ID:    123456789,
+		State: SubscriptionStateOnHold,
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *SubscriptionResponse
+		wantErr      error
+	}{
+		{
+			name: "change date",
+			args: args{
+				automaticallyResumeAt: "2021-03-01",
+				stub: func() {
+					getSubscription(SubscriptionStateOnHold)
+					client.EXPECT().Put([]byte(`{"hold":{"automatically_resume_at":"2021-03-01"}}`), "subscriptions/123456789/hold.json").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader(subscriptionBody(SubscriptionStateOnHold))),
+					}, nil)
+				},
+			},
+			wantResponse: held,
+		},
+		{
+			name: "clear date",
+			args: args{
+				stub: func() {
+					getSubscription(SubscriptionStateOnHold)
+					client.EXPECT().Put([]byte(`{"hold":{"automatically_resume_at":null}}`), "subscriptions/123456789/hold.json").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader(subscriptionBody(SubscriptionStateOnHold))),
+					}, nil)
+				},
+			},
+			wantResponse: held,
+		},
+		{
+			name: "not on hold",
+			args: args{
+				automaticallyResumeAt: "2021-03-01",
+				stub: func() {
+					getSubscription(SubscriptionStateActive)
+				},
+			},
+			wantErr: &StateError{
+				SubscriptionID: 123456789,
+				State:          SubscriptionStateActive,
+				Action:         "update automatic resume for",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.stub()
+			gotResponse, err := UpdateAutomaticResume(client, 123456789, tt.args.automaticallyResumeAt)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("UpdateAutomaticResume() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("UpdateAutomaticResume() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
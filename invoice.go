@@ -0,0 +1,201 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Invoice is a Relationship Invoicing invoice. Amounts are decimal strings in the invoice currency.
+type Invoice struct {
+	UID                 string             `json:"uid,omitempty"`
+	SiteID              int64              `json:"site_id,omitempty"`
+	CustomerID          int64              `json:"customer_id,omitempty"`
+	SubscriptionID      int64              `json:"subscription_id,omitempty"`
+	Number              string             `json:"number,omitempty"`
+	SequenceNumber      int64              `json:"sequence_number,omitempty"`
+	IssueDate           string             `json:"issue_date,omitempty"`
+	DueDate             string             `json:"due_date,omitempty"`
+	PaidDate            string             `json:"paid_date,omitempty"`
+	Status              string             `json:"status,omitempty"`
+	Role                string             `json:"role,omitempty"`
+	CollectionMethod    string             `json:"collection_method,omitempty"`
+	PaymentInstructions string             `json:"payment_instructions,omitempty"`
+	Currency            string             `json:"currency,omitempty"`
+	ConsolidationLevel  string             `json:"consolidation_level,omitempty"`
+	ProductName         string             `json:"product_name,omitempty"`
+	ProductFamilyName   string             `json:"product_family_name,omitempty"`
+	Memo                string             `json:"memo,omitempty"`
+	SubtotalAmount      string             `json:"subtotal_amount,omitempty"`
+	DiscountAmount      string             `json:"discount_amount,omitempty"`
+	TaxAmount           string             `json:"tax_amount,omitempty"`
+	TotalAmount         string             `json:"total_amount,omitempty"`
+	CreditAmount        string             `json:"credit_amount,omitempty"`
+	RefundAmount        string             `json:"refund_amount,omitempty"`
+	PaidAmount          string             `json:"paid_amount,omitempty"`
+	DueAmount           string             `json:"due_amount,omitempty"`
+	PublicURL           string             `json:"public_url,omitempty"`
+	CreatedAt           string             `json:"created_at,omitempty"`
+	UpdatedAt           string             `json:"updated_at,omitempty"`
+	LineItems           []*InvoiceLineItem `json:"line_items,omitempty"`
+	Credits             []*InvoiceCredit   `json:"credits,omitempty"`
+	Refunds             []*InvoiceRefund   `json:"refunds,omitempty"`
+}
+
+// InvoiceCredit is a credit note applied to an invoice, oldest first.
+type InvoiceCredit struct {
+	UID              string `json:"uid,omitempty"`
+	CreditNoteNumber string `json:"credit_note_number,omitempty"`
+	CreditNoteUID    string `json:"credit_note_uid,omitempty"`
+	TransactionTime  string `json:"transaction_time,omitempty"`
+	Memo             string `json:"memo,omitempty"`
+	OriginalAmount   string `json:"original_amount,omitempty"`
+	AppliedAmount    string `json:"applied_amount,omitempty"`
+}
+
+type InvoiceLineItem struct {
+	UID              string `json:"uid,omitempty"`
+	Title            string `json:"title,omitempty"`
+	Description      string `json:"description,omitempty"`
+	Quantity         string `json:"quantity,omitempty"`
+	UnitPrice        string `json:"unit_price,omitempty"`
+	SubtotalAmount   string `json:"subtotal_amount,omitempty"`
+	DiscountAmount   string `json:"discount_amount,omitempty"`
+	TaxAmount        string `json:"tax_amount,omitempty"`
+	TotalAmount      string `json:"total_amount,omitempty"`
+	TieredUnitPrice  bool   `json:"tiered_unit_price,omitempty"`
+	PeriodRangeStart string `json:"period_range_start,omitempty"`
+	PeriodRangeEnd   string `json:"period_range_end,omitempty"`
+	ProductID        int64  `json:"product_id,omitempty"`
+	ProductVersion   int64  `json:"product_version,omitempty"`
+	ComponentID      int64  `json:"component_id,omitempty"`
+	PricePointID     int64  `json:"price_point_id,omitempty"`
+}
+
+type InvoiceVoid struct {
+	Reason string `json:"reason"`
+}
+
+// Issue an advance invoice for the next period of a subscription, e.g. for prepaid annual contracts.
+// force issues a new one even when an advance invoice already exists.
+func IssueAdvanceInvoice(client Client, subscriptionID int64, force bool) (invoice *Invoice, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		Force bool `json:"force,omitempty"`
+	}{
+		Force: force,
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d/advance_invoice/issue.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Post(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	invoice = new(Invoice)
+	err = json.Unmarshal(body, invoice)
+	return
+}
+
+func GetInvoice(client Client, uid string) (invoice *Invoice, err error) {
+	if uid == "" {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("invoices/%s.json", uid)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	invoice = new(Invoice)
+	err = json.Unmarshal(body, invoice)
+	return
+}
+
+func GetAdvanceInvoice(client Client, subscriptionID int64) (invoice *Invoice, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("subscriptions/%d/advance_invoice.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	invoice = new(Invoice)
+	err = json.Unmarshal(body, invoice)
+	return
+}
+
+// Void the pending advance invoice of a subscription.
+func VoidAdvanceInvoice(client Client, subscriptionID int64, reason string) (invoice *Invoice, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	if reason == "" {
+		return nil, errors.New("no reason provided")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		VoidInvoice *InvoiceVoid `json:"void_invoice"`
+	}{
+		VoidInvoice: &InvoiceVoid{
+			Reason: reason,
+		},
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d/advance_invoice/void.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Post(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	invoice = new(Invoice)
+	err = json.Unmarshal(body, invoice)
+	return
+}
@@ -0,0 +1,145 @@
+package chargify
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestIssueAdvanceInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	issued := []byte(`{"uid":"inv_1","subscription_id":1,"status":"open","total_amount":"1200.0"}`)
+	wantInvoice := &Invoice{UID: "inv_1", SubscriptionID: 1, Status: "open", TotalAmount: "1200.0"}
+	tests := []struct {
+		name           string
+		subscriptionID int64
+		force          bool
+		stub           func()
+		wantResponse   *Invoice
+		wantErr        error
+	}{
+		{
+			name:           "issue",
+			subscriptionID: 1,
+			stub: func() {
+				client.EXPECT().Post([]byte(`{}`), "subscriptions/1/advance_invoice/issue.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader(issued)),
+				}, nil)
+			},
+			wantResponse: wantInvoice,
+		},
+		{
+			name:           "force",
+			subscriptionID: 1,
+			force:          true,
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"force":true}`), "subscriptions/1/advance_invoice/issue.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader(issued)),
+				}, nil)
+			},
+			wantResponse: wantInvoice,
+		},
+		{
+			name:           "error",
+			subscriptionID: 1,
+			stub: func() {
+				client.EXPECT().Post([]byte(`{}`), "subscriptions/1/advance_invoice/issue.json").Return(&http.Response{
+					StatusCode: 422,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"errors":["mock error"]}`))),
+				}, nil)
+			},
+			wantErr: &Error{Errors: []string{"mock error"}},
+		},
+		{
+			name:    "no subscription id",
+			wantErr: NoID(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := IssueAdvanceInvoice(client, tt.subscriptionID, tt.force)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("IssueAdvanceInvoice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("IssueAdvanceInvoice() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestVoidAdvanceInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	tests := []struct {
+		name           string
+		subscriptionID int64
+		reason         string
+		stub           func()
+		wantResponse   *Invoice
+		wantErr        error
+	}{
+		{
+			name:           "void",
+			subscriptionID: 1,
+			reason:         "contract cancelled",
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"void_invoice":{"reason":"contract cancelled"}}`), "subscriptions/1/advance_invoice/void.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"uid":"inv_1","subscription_id":1,"status":"voided"}`))),
+				}, nil)
+			},
+			wantResponse: &Invoice{UID: "inv_1", SubscriptionID: 1, Status: "voided"},
+		},
+		{
+			name:           "error",
+			subscriptionID: 1,
+			reason:         "contract cancelled",
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"void_invoice":{"reason":"contract cancelled"}}`), "subscriptions/1/advance_invoice/void.json").Return(&http.Response{
+					StatusCode: 422,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"errors":["mock error"]}`))),
+				}, nil)
+			},
+			wantErr: &Error{Errors: []string{"mock error"}},
+		},
+		{
+			name:    "no subscription id",
+			reason:  "contract cancelled",
+			wantErr: NoID(),
+		},
+		{
+			name:           "no reason",
+			subscriptionID: 1,
+			wantErr:        errors.New("no reason provided"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := VoidAdvanceInvoice(client, tt.subscriptionID, tt.reason)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("VoidAdvanceInvoice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("VoidAdvanceInvoice() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
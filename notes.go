@@ -0,0 +1,148 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+type Note struct {
+	Note *NoteBody `json:"note"`
+}
+
+type NoteBody struct {
+	ID             int64  `json:"id,omitempty"`
+	Body           string `json:"body,omitempty"`
+	SubscriptionID int64  `json:"subscription_id,omitempty"`
+	// Sticky notes are pinned to the top of the subscription in the Chargify UI. Left unchanged on update when nil.
+	Sticky    *bool  `json:"sticky,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+func GetSubscriptionNotes(client Client, subscriptionID int64, pageNumber int32, perPage int32) (notes []*Note, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("subscriptions/%d/notes.json?per_page=%d&page=%d", subscriptionID, perPage, pageNumber)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &notes)
+	return
+}
+
+func GetSubscriptionNote(client Client, subscriptionID int64, noteID int64) (note *Note, err error) {
+	if subscriptionID == 0 || noteID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("subscriptions/%d/notes/%d.json", subscriptionID, noteID)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	note = new(Note)
+	err = json.Unmarshal(body, note)
+	return
+}
+
+func CreateSubscriptionNote(client Client, subscriptionID int64, note *Note) (response *Note, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	if note == nil || note.Note == nil || note.Note.Body == "" {
+		return nil, errors.New("missing request")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(note)
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d/notes.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Post(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(Note)
+	err = json.Unmarshal(body, response)
+	return
+}
+
+func UpdateSubscriptionNote(client Client, subscriptionID int64, noteID int64, note *Note) (response *Note, err error) {
+	if subscriptionID == 0 || noteID == 0 {
+		return nil, NoID()
+	}
+	if note == nil || note.Note == nil {
+		return nil, errors.New("missing request")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(note)
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d/notes/%d.json", subscriptionID, noteID)
+	var res *http.Response
+	res, err = client.Put(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(Note)
+	err = json.Unmarshal(body, response)
+	return
+}
+
+func DeleteSubscriptionNote(client Client, subscriptionID int64, noteID int64) (err error) {
+	if subscriptionID == 0 || noteID == 0 {
+		return NoID()
+	}
+	uri := fmt.Sprintf("subscriptions/%d/notes/%d.json", subscriptionID, noteID)
+	var res *http.Response
+	res, err = client.Delete(nil, uri)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	return checkError(res)
+}
@@ -0,0 +1,97 @@
+package chargify
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestUpdateSubscriptionNote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	unpinned := false
+	stub := func(reqBody string, resBody string) func() {
+		return func() {
+			client.EXPECT().Put([]byte(reqBody), "subscriptions/1/notes/2.json").Return(&http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(resBody))),
+			}, nil)
+		}
+	}
+	tests := []struct {
+		name           string
+		subscriptionID int64
+		noteID         int64
+		note           *Note
+		stub           func()
+		wantResponse   *Note
+		wantErr        error
+	}{
+		{
+			name:           "unpin",
+			subscriptionID: 1,
+			noteID:         2,
+			note:           &Note{Note: &NoteBody{Body: "credit granted", Sticky: &unpinned}},
+			stub:           stub(`{"note":{"body":"credit granted","sticky":false}}`, `{"note":{"id":2,"body":"credit granted","subscription_id":1,"sticky":false}}`),
+			wantResponse: &Note{Note: &NoteBody{
+				ID:             2,
+				Body:           "credit granted",
+				SubscriptionID: 1,
+				Sticky:         &unpinned,
+			}},
+		},
+		{
+			// a sticky note stays pinned when only the body changes
+			name:           "edit body",
+			subscriptionID: 1,
+			noteID:         2,
+			note:           &Note{Note: &NoteBody{Body: "credit granted"}},
+			stub:           stub(`{"note":{"body":"credit granted"}}`, `{"note":{"id":2,"body":"credit granted","subscription_id":1}}`),
+			wantResponse: &Note{Note: &NoteBody{
+				ID:             2,
+				Body:           "credit granted",
+				SubscriptionID: 1,
+			}},
+		},
+		{
+			name:           "no note id",
+			subscriptionID: 1,
+			note:           &Note{Note: &NoteBody{Body: "credit granted"}},
+			wantErr:        NoID(),
+		},
+		{
+			name:           "no note",
+			subscriptionID: 1,
+			noteID:         2,
+			note:           &Note{},
+			wantErr:        errors.New("missing request"),
+		},
+		{
+			name:           "nil note",
+			subscriptionID: 1,
+			noteID:         2,
+			wantErr:        errors.New("missing request"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := UpdateSubscriptionNote(client, tt.subscriptionID, tt.noteID, tt.note)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("UpdateSubscriptionNote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("UpdateSubscriptionNote() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
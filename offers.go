@@ -0,0 +1,171 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+type Offer struct {
+	Offer *OfferBody `json:"offer"`
+}
+
+type OfferBody struct {
+	ID                    int64            `json:"id,omitempty"`
+	SiteID                int64            `json:"site_id,omitempty"`
+	ProductFamilyID       int64            `json:"product_family_id,omitempty"`
+	ProductFamilyName     string           `json:"product_family_name,omitempty"`
+	ProductID             int64            `json:"product_id,omitempty"`
+	ProductName           string           `json:"product_name,omitempty"`
+	ProductPricePointID   int64            `json:"product_price_point_id,omitempty"`
+	ProductPricePointName string           `json:"product_price_point_name,omitempty"`
+	ProductPriceInCents   int64            `json:"product_price_in_cents,omitempty"`
+	Name                  string           `json:"name,omitempty"`
+	Handle                string           `json:"handle,omitempty"`
+	Description           string           `json:"description,omitempty"`
+	CreatedAt             string           `json:"created_at,omitempty"`
+	UpdatedAt             string           `json:"updated_at,omitempty"`
+	ArchivedAt            string           `json:"archived_at,omitempty"`
+	OfferItems            []*OfferItem     `json:"offer_items,omitempty"`
+	OfferDiscounts        []*OfferDiscount `json:"offer_discounts,omitempty"`
+	// Request
+	Components []*OfferItem `json:"components,omitempty"`
+	Coupons    []string     `json:"coupons,omitempty"`
+}
+
+type OfferItem struct {
+	ComponentID        int64  `json:"component_id,omitempty"`
+	PricePointID       int64  `json:"price_point_id,omitempty"`
+	StartingQuantity   string `json:"starting_quantity,omitempty"`
+	Editable           bool   `json:"editable,omitempty"`
+	ComponentUnitPrice string `json:"component_unit_price,omitempty"`
+	ComponentName      string `json:"component_name,omitempty"`
+	PricePointName     string `json:"price_point_name,omitempty"`
+}
+
+type OfferDiscount struct {
+	CouponCode string `json:"coupon_code,omitempty"`
+	CouponID   int64  `json:"coupon_id,omitempty"`
+	CouponName string `json:"coupon_name,omitempty"`
+}
+
+func GetOffers(client Client, includeArchived bool) (offers []*Offer, err error) {
+	uri := "offers.json"
+	if includeArchived {
+		uri += "?include_archived=true"
+	}
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nestedOffers := new(struct {
+		Offers []*OfferBody `json:"offers"`
+	})
+	if err = json.Unmarshal(body, nestedOffers); err != nil {
+		return
+	}
+	// the list is not wrapped per offer like the other endpoints
+	for _, o := range nestedOffers.Offers {
+		offers = append(offers, &Offer{Offer: o})
+	}
+	return
+}
+
+func GetOffer(client Client, offerID int64) (offer *Offer, err error) {
+	if offerID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("offers/%d.json", offerID)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	offer = new(Offer)
+	err = json.Unmarshal(body, offer)
+	return
+}
+
+// Create an offer bundling a product price point with components and coupons.
+func CreateOffer(client Client, offer *Offer) (response *Offer, err error) {
+	if offer == nil || offer.Offer == nil {
+		return nil, errors.New("missing request")
+	}
+	if offer.Offer.ProductID == 0 {
+		return nil, errors.New("no product id provided")
+	}
+	if offer.Offer.Name == "" || offer.Offer.Handle == "" {
+		return nil, errors.New("offers need a name and handle")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(offer)
+	if err != nil {
+		return
+	}
+	var res *http.Response
+	res, err = client.Post(jsonReq, "offers.json")
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(Offer)
+	err = json.Unmarshal(body, response)
+	return
+}
+
+func ArchiveOffer(client Client, offerID int64) (err error) {
+	if offerID == 0 {
+		return NoID()
+	}
+	uri := fmt.Sprintf("offers/%d/archive.json", offerID)
+	var res *http.Response
+	res, err = client.Put(nil, uri)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	return checkError(res)
+}
+
+func UnarchiveOffer(client Client, offerID int64) (err error) {
+	if offerID == 0 {
+		return NoID()
+	}
+	uri := fmt.Sprintf("offers/%d/unarchive.json", offerID)
+	var res *http.Response
+	res, err = client.Put(nil, uri)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	return checkError(res)
+}
@@ -0,0 +1,96 @@
+package chargify
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestGetOffers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("offers.json?include_archived=true").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"offers":[{"id":1,"handle":"launch"},{"id":2,"handle":"old","archived_at":"2021-01-01T00:00:00Z"}]}`))),
+	}, nil)
+	want := []*Offer{
+		{Offer: &OfferBody{ID: 1, Handle: "launch"}},
+		{Offer: &OfferBody{ID: 2, Handle: "old", ArchivedAt: "2021-01-01T00:00:00Z"}},
+	}
+	got, err := GetOffers(client, true)
+	if err != nil {
+		t.Fatalf("GetOffers() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetOffers() = %v, want %v", got, want)
+	}
+}
+
+func TestCreateOffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	offer := &Offer{
+		Offer: &OfferBody{
+			ProductID:           5,
+			ProductPricePointID: 6,
+			Name:                "Launch",
+			Handle:              "launch",
+			Components:          []*OfferItem{{ComponentID: 7, StartingQuantity: "1"}},
+			Coupons:             []string{"LAUNCH10"},
+		},
+	}
+	tests := []struct {
+		name         string
+		offer        *Offer
+		stub         func()
+		wantResponse *Offer
+		wantErr      error
+	}{
+		{
+			name:  "create",
+			offer: offer,
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"offer":{"product_id":5,"product_price_point_id":6,"name":"Launch","handle":"launch","components":[{"component_id":7,"starting_quantity":"1"}],"coupons":["LAUNCH10"]}}`), "offers.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"offer":{"id":1,"product_id":5,"handle":"launch"}}`))),
+				}, nil)
+			},
+			wantResponse: &Offer{Offer: &OfferBody{ID: 1, ProductID: 5, Handle: "launch"}},
+		},
+		{
+			name:    "no request",
+			wantErr: errors.New("missing request"),
+		},
+		{
+			name:    "no product",
+			offer:   &Offer{Offer: &OfferBody{Name: "Launch", Handle: "launch"}},
+			wantErr: errors.New("no product id provided"),
+		},
+		{
+			name:    "no handle",
+			offer:   &Offer{Offer: &OfferBody{ProductID: 5, Name: "Launch"}},
+			wantErr: errors.New("offers need a name and handle"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := CreateOffer(client, tt.offer)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("CreateOffer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("CreateOffer() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
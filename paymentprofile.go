@@ -30,6 +30,19 @@ type PaymentProfile struct {
 	CustomerVaultToken string `json:"customer_vault_token,omitempty"`
 	PaypalEmail        string `json:"paypal_email,omitempty"`
 	PaypalMethodNonce  string `json:"paypal_method_nonce,omitempty"`
+	// bank account profiles
+	BankName              string `json:"bank_name,omitempty"`
+	BankRoutingNumber     string `json:"bank_routing_number,omitempty"`
+	BankAccountNumber     string `json:"bank_account_number,omitempty"`
+	BankAccountType       string `json:"bank_account_type,omitempty"`
+	BankBranchCode        string `json:"bank_branch_code,omitempty"`
+	BankIBAN              string `json:"bank_iban,omitempty"`
+	BankAccountHolderType string `json:"bank_account_holder_type,omitempty"`
+	// bank account read-back
+	MaskedBankRoutingNumber string `json:"masked_bank_routing_number,omitempty"`
+	MaskedBankAccountNumber string `json:"masked_bank_account_number,omitempty"`
+	Verified                bool   `json:"verified,omitempty"`
+	Disabled                bool   `json:"disabled,omitempty"`
 }
 
 type PaymentProfileRequest struct {
@@ -101,3 +114,151 @@ func (pp *PaymentProfile) Update(client Client) (response *PaymentProfileRespons
 	return
 
 }
+
+// Create a payment profile for an existing customer, either from a Chargify.js token or raw card or bank details.
+func (pp *PaymentProfile) Create(client Client) (response *PaymentProfile, err error) {
+	if pp.CustomerID == 0 {
+		return nil, errors.New("no customer id present")
+	}
+	if pp.ChargifyToken == "" && pp.FullNumber == "" && pp.BankAccountNumber == "" && pp.BankIBAN == "" {
+		return nil, errors.New("no chargify token, card or bank account present")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&PaymentProfileRequest{
+		PaymentProfile: pp,
+	})
+	if err != nil {
+		return
+	}
+	var res *http.Response
+	res, err = client.Post(jsonReq, "payment_profiles.json")
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nested := new(PaymentProfileRequest)
+	if err = json.Unmarshal(body, nested); err != nil {
+		return
+	}
+	return nested.PaymentProfile, nil
+}
+
+func GetPaymentProfile(client Client, paymentProfileID int64) (response *PaymentProfile, err error) {
+	if paymentProfileID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("payment_profiles/%d.json", paymentProfileID)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nested := new(PaymentProfileRequest)
+	if err = json.Unmarshal(body, nested); err != nil {
+		return
+	}
+	return nested.PaymentProfile, nil
+}
+
+func GetCustomerPaymentProfiles(client Client, customerID int64) (paymentProfiles []*PaymentProfile, err error) {
+	if customerID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("payment_profiles.json?customer_id=%d", customerID)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	var nested []*PaymentProfileRequest
+	if err = json.Unmarshal(body, &nested); err != nil {
+		return
+	}
+	for _, p := range nested {
+		paymentProfiles = append(paymentProfiles, p.PaymentProfile)
+	}
+	return
+}
+
+// Delete a payment profile that is not in use by any subscription.
+func DeletePaymentProfile(client Client, paymentProfileID int64) (err error) {
+	if paymentProfileID == 0 {
+		return NoID()
+	}
+	uri := fmt.Sprintf("payment_profiles/%d.json", paymentProfileID)
+	var res *http.Response
+	res, err = client.Delete(nil, uri)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	return checkError(res)
+}
+
+// Delete a payment profile attached to a subscription.
+func DeleteSubscriptionPaymentProfile(client Client, subscriptionID int64, paymentProfileID int64) (err error) {
+	if subscriptionID == 0 || paymentProfileID == 0 {
+		return NoID()
+	}
+	uri := fmt.Sprintf("subscriptions/%d/payment_profiles/%d.json", subscriptionID, paymentProfileID)
+	var res *http.Response
+	res, err = client.Delete(nil, uri)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	return checkError(res)
+}
+
+// Make an existing payment profile of the customer the default for a subscription.
+func ChangeDefaultPaymentProfile(client Client, subscriptionID int64, paymentProfileID int64) (response *PaymentProfile, err error) {
+	if subscriptionID == 0 || paymentProfileID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("subscriptions/%d/payment_profiles/%d/change_payment_profile.json", subscriptionID, paymentProfileID)
+	var res *http.Response
+	res, err = client.Post(nil, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nested := new(PaymentProfileRequest)
+	if err = json.Unmarshal(body, nested); err != nil {
+		return
+	}
+	return nested.PaymentProfile, nil
+}
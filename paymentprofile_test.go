@@ -0,0 +1,99 @@
+package chargify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestPaymentProfile_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	res := &PaymentProfile{
+		ID:               1,
+		CustomerID:       2,
+		MaskedCardNumber: "XXXX-XXXX-XXXX-1111",
+	}
+	body, err := json.Marshal(&PaymentProfileRequest{PaymentProfile: res})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		pp           *PaymentProfile
+		stub         func()
+		wantResponse *PaymentProfile
+		wantErr      error
+	}{
+		{
+			name: "create from token",
+			pp: &PaymentProfile{
+				CustomerID:    2,
+				ChargifyToken: "tok_123",
+			},
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"payment_profile":{"customer_id":2,"chargify_token":"tok_123"}}`), "payment_profiles.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader(body)),
+				}, nil)
+			},
+			wantResponse: res,
+		},
+		{
+			name: "create no customer",
+			pp: &PaymentProfile{
+				ChargifyToken: "tok_123",
+			},
+			wantErr: errors.New("no customer id present"),
+		},
+		{
+			name: "create no payment method",
+			pp: &PaymentProfile{
+				CustomerID: 2,
+			},
+			wantErr: errors.New("no chargify token, card or bank account present"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := tt.pp.Create(client)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("PaymentProfile.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("PaymentProfile.Create() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestGetCustomerPaymentProfiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	want := []*PaymentProfile{
+		{ID: 1, CustomerID: 2},
+		{ID: 3, CustomerID: 2},
+	}
+	client.EXPECT().Get("payment_profiles.json?customer_id=2").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"payment_profile":{"id":1,"customer_id":2}},{"payment_profile":{"id":3,"customer_id":2}}]`))),
+	}, nil)
+	got, err := GetCustomerPaymentProfiles(client, 2)
+	if err != nil {
+		t.Fatalf("GetCustomerPaymentProfiles() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetCustomerPaymentProfiles() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,65 @@
+package chargify
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// fetches a pdf without reading it into memory, the caller closes the body
+func getPDF(client Client, uri string) (io.ReadCloser, error) {
+	res, err := client.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkError(res); err != nil {
+		if res.Body != nil {
+			res.Body.Close()
+		}
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// Stream a statement's pdf. The caller must close it.
+func DownloadStatement(client Client, statementID int64) (io.ReadCloser, error) {
+	if statementID == 0 {
+		return nil, NoID()
+	}
+	return getPDF(client, fmt.Sprintf("statements/%d.pdf", statementID))
+}
+
+// Stream an invoice's pdf. The caller must close it.
+func DownloadInvoice(client Client, uid string) (io.ReadCloser, error) {
+	if uid == "" {
+		return nil, NoID()
+	}
+	return getPDF(client, fmt.Sprintf("invoices/%s.pdf", uid))
+}
+
+// WritePDF copies a pdf from one of the Download functions to w and closes it.
+func WritePDF(w io.Writer, pdf io.ReadCloser) (int64, error) {
+	defer pdf.Close()
+	return io.Copy(w, pdf)
+}
+
+// SavePDF writes a pdf from one of the Download functions to path and closes it.
+// Nothing is left at path when the download fails part way.
+func SavePDF(path string, pdf io.ReadCloser) (err error) {
+	defer pdf.Close()
+	var f *os.File
+	f, err = os.Create(path)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+	_, err = io.Copy(f, pdf)
+	return
+}
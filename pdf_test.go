@@ -0,0 +1,67 @@
+package chargify
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestDownloadStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("statements/42.pdf").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte("%PDF-1.4"))),
+	}, nil)
+	pdf, err := DownloadStatement(client, 42)
+	if err != nil {
+		t.Fatalf("DownloadStatement() error = %v", err)
+	}
+	var buf bytes.Buffer
+	n, err := WritePDF(&buf, pdf)
+	if err != nil || n != 8 || buf.String() != "%PDF-1.4" {
+		t.Errorf("WritePDF() = %d, %v, wrote %q", n, err, buf.String())
+	}
+	client.EXPECT().Get("statements/43.pdf").Return(&http.Response{
+		StatusCode: 404,
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}, nil)
+	if _, err = DownloadStatement(client, 43); err != NotFound {
+		t.Errorf("DownloadStatement() error = %v, want %v", err, NotFound)
+	}
+}
+
+func TestSavePDF(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chargify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "statement.pdf")
+	if err = SavePDF(path, ioutil.NopCloser(bytes.NewReader([]byte("%PDF-1.4")))); err != nil {
+		t.Fatalf("SavePDF() error = %v", err)
+	}
+	if b, _ := ioutil.ReadFile(path); string(b) != "%PDF-1.4" {
+		t.Errorf("SavePDF() wrote %q", b)
+	}
+	failed := filepath.Join(dir, "failed.pdf")
+	if err = SavePDF(failed, ioutil.NopCloser(failingReader{})); err == nil {
+		t.Error("SavePDF() expected an error")
+	}
+	if _, err = os.Stat(failed); !os.IsNotExist(err) {
+		t.Errorf("SavePDF() left %s behind", failed)
+	}
+}
@@ -0,0 +1,226 @@
+package chargify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type PortalInvitation struct {
+	UninvitedCount int64  `json:"uninvited_count,omitempty"`
+	LastSentAt     string `json:"last_sent_at,omitempty"`
+	LastAcceptedAt string `json:"last_accepted_at,omitempty"`
+	SendInviteLink bool   `json:"send_invite_link,omitempty"`
+}
+
+type ManagementLink struct {
+	URL                  string `json:"url,omitempty"`
+	FetchCount           int64  `json:"fetch_count,omitempty"`
+	CreatedAt            string `json:"created_at,omitempty"`
+	NewLinkAvailableAt   string `json:"new_link_available_at,omitempty"`
+	ExpiresAt            string `json:"expires_at,omitempty"`
+	LastInviteSentAt     string `json:"last_invite_sent_at,omitempty"`
+	LastInviteAcceptedAt string `json:"last_invite_accepted_at,omitempty"`
+	LastInviteRevokedAt  string `json:"last_invite_revoked_at,omitempty"`
+}
+
+// Enable the Billing Portal for a customer, optionally emailing them an invitation.
+func EnableBillingPortal(client Client, customerID int64, invite bool) (customer *Customer, err error) {
+	if customerID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("portal/customers/%d/enable.json", customerID)
+	if invite {
+		uri += "?invite=1"
+	}
+	var res *http.Response
+	res, err = client.Post(nil, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	customer = new(Customer)
+	err = json.Unmarshal(body, customer)
+	return
+}
+
+// Send the Billing Portal invitation email to a customer again.
+func ResendBillingPortalInvitation(client Client, customerID int64) (invitation *PortalInvitation, err error) {
+	if customerID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("portal/customers/%d/invitations/invite.json", customerID)
+	var res *http.Response
+	res, err = client.Post(nil, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	invitation = new(PortalInvitation)
+	err = json.Unmarshal(body, invitation)
+	return
+}
+
+// Revoke a customer's Billing Portal access. Chargify has no separate disable call, this is how the portal is turned off for a customer.
+func RevokeBillingPortalAccess(client Client, customerID int64) (err error) {
+	if customerID == 0 {
+		return NoID()
+	}
+	uri := fmt.Sprintf("portal/customers/%d/invitations/revoke.json", customerID)
+	var res *http.Response
+	res, err = client.Delete(nil, uri)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	return checkError(res)
+}
+
+// Fetch a short-lived Billing Portal link for a customer. Chargify rate limits new links,
+// use a ManagementLinkCache to reuse links until they expire.
+func GetManagementLink(client Client, customerID int64) (link *ManagementLink, err error) {
+	if customerID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("portal/customers/%d/management_link.json", customerID)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	link = new(ManagementLink)
+	err = json.Unmarshal(body, link)
+	return
+}
+
+// ManagementLinkUnavailableError is returned by ManagementLinkCache.Get when the cached link has expired and
+// Chargify does not hand out a new one before AvailableAt.
+type ManagementLinkUnavailableError struct {
+	CustomerID  int64
+	AvailableAt time.Time
+}
+
+func (e *ManagementLinkUnavailableError) Error() string {
+	return fmt.Sprintf("no new management link for customer %d before %s", e.CustomerID, e.AvailableAt.Format(time.RFC3339))
+}
+
+// ManagementLinkCache reuses management links until shortly before they expire, and does not ask for a new link
+// before new_link_available_at. Concurrent Gets for the same customer share a single fetch.
+type ManagementLinkCache struct {
+	client   Client
+	mu       sync.Mutex
+	links    map[int64]*cachedManagementLink
+	fetching map[int64]*managementLinkFetch
+	now      func() time.Time
+}
+
+type cachedManagementLink struct {
+	link *ManagementLink
+	// refreshAt is expiresAt less the margin, the link is replaced from then on when chargify allows it
+	refreshAt   time.Time
+	expiresAt   time.Time
+	availableAt time.Time
+}
+
+type managementLinkFetch struct {
+	done chan struct{}
+	link *ManagementLink
+	err  error
+}
+
+// links are dropped this long before they expire so callers have time to use them
+const managementLinkMargin = time.Minute
+
+func NewManagementLinkCache(client Client) *ManagementLinkCache {
+	return &ManagementLinkCache{
+		client:   client,
+		links:    make(map[int64]*cachedManagementLink),
+		fetching: make(map[int64]*managementLinkFetch),
+		now:      time.Now,
+	}
+}
+
+func (c *ManagementLinkCache) Get(customerID int64) (*ManagementLink, error) {
+	c.mu.Lock()
+	if cached, ok := c.links[customerID]; ok {
+		now := c.now()
+		if now.Before(cached.refreshAt) {
+			c.mu.Unlock()
+			return cached.link, nil
+		}
+		if now.Before(cached.availableAt) {
+			c.mu.Unlock()
+			// inside the margin the old link still works, past its expiry there is nothing to hand out
+			if now.Before(cached.expiresAt) {
+				return cached.link, nil
+			}
+			return nil, &ManagementLinkUnavailableError{CustomerID: customerID, AvailableAt: cached.availableAt}
+		}
+		delete(c.links, customerID)
+	}
+	if fetch, ok := c.fetching[customerID]; ok {
+		c.mu.Unlock()
+		<-fetch.done
+		return fetch.link, fetch.err
+	}
+	fetch := &managementLinkFetch{done: make(chan struct{})}
+	c.fetching[customerID] = fetch
+	c.mu.Unlock()
+
+	fetch.link, fetch.err = GetManagementLink(c.client, customerID)
+
+	c.mu.Lock()
+	delete(c.fetching, customerID)
+	// links without a readable expiry are not cached
+	if fetch.err == nil {
+		if expiresAt, err := time.Parse(time.RFC3339, fetch.link.ExpiresAt); err == nil {
+			cached := &cachedManagementLink{
+				link:      fetch.link,
+				refreshAt: expiresAt.Add(-managementLinkMargin),
+				expiresAt: expiresAt,
+			}
+			if availableAt, err := time.Parse(time.RFC3339, fetch.link.NewLinkAvailableAt); err == nil {
+				cached.availableAt = availableAt
+			}
+			c.links[customerID] = cached
+		}
+	}
+	c.mu.Unlock()
+	close(fetch.done)
+	return fetch.link, fetch.err
+}
+
+// Forget drops the cached link of a customer, e.g. after their access was revoked.
+func (c *ManagementLinkCache) Forget(customerID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.links, customerID)
+}
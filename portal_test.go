@@ -0,0 +1,108 @@
+package chargify
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestManagementLinkCache_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	linkBody := func(url string) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"url":"` + url + `","expires_at":"2021-01-01T01:00:00Z"}`))),
+		}
+	}
+	gomock.InOrder(
+		client.EXPECT().Get("portal/customers/1/management_link.json").Return(linkBody("https://first"), nil),
+		client.EXPECT().Get("portal/customers/1/management_link.json").Return(linkBody("https://second"), nil),
+	)
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewManagementLinkCache(client)
+	cache.now = func() time.Time { return now }
+	for _, step := range []struct {
+		at   time.Duration
+		want string
+	}{
+		{0, "https://first"},
+		{30 * time.Minute, "https://first"},
+		// inside the expiry margin, fetch a new one
+		{59*time.Minute + 30*time.Second, "https://second"},
+	} {
+		cache.now = func() time.Time { return now.Add(step.at) }
+		link, err := cache.Get(1)
+		if err != nil {
+			t.Fatalf("ManagementLinkCache.Get() error = %v", err)
+		}
+		if link.URL != step.want {
+			t.Errorf("ManagementLinkCache.Get() at %v = %v, want %v", step.at, link.URL, step.want)
+		}
+	}
+}
+
+func TestManagementLinkCache_Get_newLinkAvailableAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("portal/customers/1/management_link.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"url":"https://first","expires_at":"2021-01-01T01:00:00Z","new_link_available_at":"2021-01-01T02:00:00Z"}`))),
+	}, nil)
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewManagementLinkCache(client)
+	for _, step := range []struct {
+		at      time.Duration
+		want    string
+		wantErr error
+	}{
+		{0, "https://first", nil},
+		// inside the margin but chargify won't make a new link yet
+		{59*time.Minute + 30*time.Second, "https://first", nil},
+		{90 * time.Minute, "", &ManagementLinkUnavailableError{CustomerID: 1, AvailableAt: now.Add(2 * time.Hour)}},
+	} {
+		cache.now = func() time.Time { return now.Add(step.at) }
+		link, err := cache.Get(1)
+		if !reflect.DeepEqual(err, step.wantErr) {
+			t.Fatalf("ManagementLinkCache.Get() at %v error = %v, wantErr %v", step.at, err, step.wantErr)
+		}
+		if err == nil && link.URL != step.want {
+			t.Errorf("ManagementLinkCache.Get() at %v = %v, want %v", step.at, link.URL, step.want)
+		}
+	}
+}
+
+func TestManagementLinkCache_Get_concurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	release := make(chan struct{})
+	client.EXPECT().Get("portal/customers/1/management_link.json").DoAndReturn(func(string) (*http.Response, error) {
+		<-release
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"url":"https://first","expires_at":"2021-01-01T01:00:00Z"}`))),
+		}, nil
+	}).Times(1)
+	cache := NewManagementLinkCache(client)
+	cache.now = func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) }
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			link, err := cache.Get(1)
+			if err != nil || link.URL != "https://first" {
+				t.Errorf("ManagementLinkCache.Get() = %v, %v", link, err)
+			}
+		}()
+	}
+	close(release)
+	wg.Wait()
+}
@@ -0,0 +1,220 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+type PrepaidPurchase struct {
+	Quantity               int64  `json:"quantity"`
+	Memo                   string `json:"memo,omitempty"`
+	PricePointID           int64  `json:"price_point_id,omitempty"`
+	ExpirationInterval     int64  `json:"expiration_interval,omitempty"`
+	ExpirationIntervalUnit string `json:"expiration_interval_unit,omitempty"`
+}
+
+// PrepaidUsage is the record of a prepaid purchase.
+type PrepaidUsage struct {
+	ID                     int64  `json:"id,omitempty"`
+	Memo                   string `json:"memo,omitempty"`
+	CreatedAt              string `json:"created_at,omitempty"`
+	PricePointID           int64  `json:"price_point_id,omitempty"`
+	Quantity               int64  `json:"quantity,omitempty"`
+	OverageQuantity        int64  `json:"overage_quantity,omitempty"`
+	ComponentID            int64  `json:"component_id,omitempty"`
+	ComponentHandle        string `json:"component_handle,omitempty"`
+	SubscriptionID         int64  `json:"subscription_id,omitempty"`
+	PeriodRangeStart       string `json:"period_range_start,omitempty"`
+	PeriodRangeEnd         string `json:"period_range_end,omitempty"`
+	ExpirationInterval     int64  `json:"expiration_interval,omitempty"`
+	ExpirationIntervalUnit string `json:"expiration_interval_unit,omitempty"`
+}
+
+type PrepaidAllocation struct {
+	AllocationID             int64  `json:"allocation_id,omitempty"`
+	ComponentID              int64  `json:"component_id,omitempty"`
+	SubscriptionID           int64  `json:"subscription_id,omitempty"`
+	Quantity                 int64  `json:"quantity,omitempty"`
+	PreviousQuantity         int64  `json:"previous_quantity,omitempty"`
+	Memo                     string `json:"memo,omitempty"`
+	Timestamp                string `json:"timestamp,omitempty"`
+	PricePointID             int64  `json:"price_point_id,omitempty"`
+	ExpiresAt                string `json:"expires_at,omitempty"`
+	ExpirationInterval       int64  `json:"expiration_interval,omitempty"`
+	ExpirationIntervalUnit   string `json:"expiration_interval_unit,omitempty"`
+	RolloverPrepaidRemainder bool   `json:"rollover_prepaid_remainder,omitempty"`
+	RenewPrepaidAllocation   bool   `json:"renew_prepaid_allocation,omitempty"`
+}
+
+type PrepaidBalance struct {
+	ComponentID int64
+	UnitBalance int64
+	// Allocations that have not expired yet
+	Allocations []*PrepaidAllocation
+	// NextExpiration is the earliest expires_at of Allocations, empty when none expire
+	NextExpiration string
+}
+
+type Usage struct {
+	Usage *UsageBody `json:"usage"`
+}
+
+type UsageBody struct {
+	ID              int64  `json:"id,omitempty"`
+	Memo            string `json:"memo,omitempty"`
+	CreatedAt       string `json:"created_at,omitempty"`
+	PricePointID    int64  `json:"price_point_id,omitempty"`
+	Quantity        int64  `json:"quantity,omitempty"`
+	ComponentID     int64  `json:"component_id,omitempty"`
+	ComponentHandle string `json:"component_handle,omitempty"`
+	SubscriptionID  int64  `json:"subscription_id,omitempty"`
+}
+
+// Buy prepaid units of a prepaid usage component for a subscription.
+func PurchasePrepaidUnits(client Client, subscriptionID int64, componentID int64, purchase *PrepaidPurchase) (usage *PrepaidUsage, err error) {
+	if subscriptionID == 0 || componentID == 0 {
+		return nil, NoID()
+	}
+	if purchase == nil {
+		return nil, errors.New("missing request")
+	}
+	if purchase.Quantity <= 0 {
+		return nil, errors.New("quantity must be positive")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		PrepaidUsage *PrepaidPurchase `json:"prepaid_usage"`
+	}{
+		PrepaidUsage: purchase,
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d/components/%d/prepaid_usages.json", subscriptionID, componentID)
+	var res *http.Response
+	res, err = client.Post(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nestedUsage := new(struct {
+		PrepaidUsage *PrepaidUsage `json:"prepaid_usage"`
+	})
+	if err = json.Unmarshal(body, nestedUsage); err != nil {
+		return
+	}
+	return nestedUsage.PrepaidUsage, nil
+}
+
+func GetPrepaidAllocations(client Client, subscriptionID int64, componentID int64) (allocations []*PrepaidAllocation, err error) {
+	if subscriptionID == 0 || componentID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("subscriptions/%d/components/%d/allocations.json", subscriptionID, componentID)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	var nestedAllocations []*struct {
+		Allocation *PrepaidAllocation `json:"allocation"`
+	}
+	if err = json.Unmarshal(body, &nestedAllocations); err != nil {
+		return
+	}
+	for _, a := range nestedAllocations {
+		allocations = append(allocations, a.Allocation)
+	}
+	return
+}
+
+// Get the remaining prepaid units of a component along with the allocations they come from and when they expire.
+func GetPrepaidBalance(client Client, subscriptionID int64, componentID int64) (*PrepaidBalance, error) {
+	if subscriptionID == 0 || componentID == 0 {
+		return nil, NoID()
+	}
+	component, err := GetComponentAllocation(client, subscriptionID, componentID)
+	if err != nil {
+		return nil, err
+	}
+	if component.Component == nil {
+		return nil, NotFound
+	}
+	allocations, err := GetPrepaidAllocations(client, subscriptionID, componentID)
+	if err != nil {
+		return nil, err
+	}
+	return prepaidBalance(component.Component, allocations, time.Now()), nil
+}
+
+func prepaidBalance(component *ComponentBody, allocations []*PrepaidAllocation, now time.Time) *PrepaidBalance {
+	balance := &PrepaidBalance{
+		ComponentID: component.ComponentID,
+		UnitBalance: component.UnitBalance,
+	}
+	var next time.Time
+	for _, a := range allocations {
+		if a == nil {
+			continue
+		}
+		if a.ExpiresAt == "" {
+			balance.Allocations = append(balance.Allocations, a)
+			continue
+		}
+		expiresAt, err := parseDate(a.ExpiresAt)
+		if err != nil || !expiresAt.After(now) {
+			continue
+		}
+		balance.Allocations = append(balance.Allocations, a)
+		if next.IsZero() || expiresAt.Before(next) {
+			next = expiresAt
+			balance.NextExpiration = a.ExpiresAt
+		}
+	}
+	return balance
+}
+
+// Get the usage recorded against a prepaid component, newest first.
+func GetPrepaidUsageHistory(client Client, subscriptionID int64, componentID int64, pageNumber int32, perPage int32) (usages []*Usage, err error) {
+	if subscriptionID == 0 || componentID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("subscriptions/%d/components/%d/usages.json?per_page=%d&page=%d", subscriptionID, componentID, perPage, pageNumber)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &usages)
+	return
+}
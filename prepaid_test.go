@@ -0,0 +1,137 @@
+package chargify
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_prepaidBalance(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	expired := &PrepaidAllocation{AllocationID: 1, Quantity: 100, ExpiresAt: "2021-05-01T00:00:00Z"}
+	later := &PrepaidAllocation{AllocationID: 2, Quantity: 50, ExpiresAt: "2021-12-01T00:00:00Z"}
+	sooner := &PrepaidAllocation{AllocationID: 3, Quantity: 25, ExpiresAt: "2021-07-01"}
+	forever := &PrepaidAllocation{AllocationID: 4, Quantity: 10}
+	got := prepaidBalance(&ComponentBody{ComponentID: 9, UnitBalance: 60}, []*PrepaidAllocation{expired, later, sooner, forever}, now)
+	want := &PrepaidBalance{
+		ComponentID:    9,
+		UnitBalance:    60,
+		Allocations:    []*PrepaidAllocation{later, sooner, forever},
+		NextExpiration: "2021-07-01",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("prepaidBalance() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPurchasePrepaidUnits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	tests := []struct {
+		name         string
+		purchase     *PrepaidPurchase
+		stub         func()
+		wantResponse *PrepaidUsage
+		wantErr      error
+	}{
+		{
+			name: "purchase",
+			purchase: &PrepaidPurchase{
+				Quantity:               100,
+				Memo:                   "top up",
+				ExpirationInterval:     1,
+				ExpirationIntervalUnit: "month",
+			},
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"prepaid_usage":{"quantity":100,"memo":"top up","expiration_interval":1,"expiration_interval_unit":"month"}}`), "subscriptions/1/components/2/prepaid_usages.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"prepaid_usage":{"id":5,"quantity":100,"memo":"top up","component_id":2,"subscription_id":1}}`))),
+				}, nil)
+			},
+			wantResponse: &PrepaidUsage{
+				ID:             5,
+				Quantity:       100,
+				Memo:           "top up",
+				ComponentID:    2,
+				SubscriptionID: 1,
+			},
+		},
+		{
+			name:     "no quantity",
+			purchase: &PrepaidPurchase{},
+			wantErr:  errors.New("quantity must be positive"),
+		},
+		{
+			name:    "no request",
+			wantErr: errors.New("missing request"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := PurchasePrepaidUnits(client, 1, 2, tt.purchase)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("PurchasePrepaidUnits() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("PurchasePrepaidUnits() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestGetPrepaidAllocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("subscriptions/1/components/2/allocations.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"allocation":{"allocation_id":3,"quantity":100,"expires_at":"2021-07-01T00:00:00Z"}},{"allocation":{"allocation_id":4,"quantity":50}}]`))),
+	}, nil)
+	want := []*PrepaidAllocation{
+		{AllocationID: 3, Quantity: 100, ExpiresAt: "2021-07-01T00:00:00Z"},
+		{AllocationID: 4, Quantity: 50},
+	}
+	got, err := GetPrepaidAllocations(client, 1, 2)
+	if err != nil {
+		t.Fatalf("GetPrepaidAllocations() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPrepaidAllocations() = %v, want %v", got, want)
+	}
+}
+
+func TestGetPrepaidUsageHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("subscriptions/1/components/2/usages.json?per_page=20&page=1").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"usage":{"id":9,"quantity":10,"memo":"api calls","component_id":2,"subscription_id":1}}]`))),
+	}, nil)
+	want := []*Usage{{Usage: &UsageBody{
+		ID:             9,
+		Quantity:       10,
+		Memo:           "api calls",
+		ComponentID:    2,
+		SubscriptionID: 1,
+	}}}
+	got, err := GetPrepaidUsageHistory(client, 1, 2, 1, 20)
+	if err != nil {
+		t.Fatalf("GetPrepaidUsageHistory() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPrepaidUsageHistory() = %v, want %v", got, want)
+	}
+	if _, err = GetPrepaidUsageHistory(client, 0, 2, 1, 20); !reflect.DeepEqual(err, NoID()) {
+		t.Errorf("GetPrepaidUsageHistory() error = %v, wantErr %v", err, NoID())
+	}
+}
@@ -0,0 +1,147 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// ProformaInvoice shows what a subscription or group will be billed, without collecting payment.
+type ProformaInvoice struct {
+	UID                 string             `json:"uid,omitempty"`
+	SiteID              int64              `json:"site_id,omitempty"`
+	CustomerID          int64              `json:"customer_id,omitempty"`
+	SubscriptionID      int64              `json:"subscription_id,omitempty"`
+	Number              int64              `json:"number,omitempty"`
+	SequenceNumber      int64              `json:"sequence_number,omitempty"`
+	CreatedAt           string             `json:"created_at,omitempty"`
+	DeliveryDate        string             `json:"delivery_date,omitempty"`
+	Status              string             `json:"status,omitempty"`
+	CollectionMethod    string             `json:"collection_method,omitempty"`
+	PaymentInstructions string             `json:"payment_instructions,omitempty"`
+	Currency            string             `json:"currency,omitempty"`
+	ConsolidationLevel  string             `json:"consolidation_level,omitempty"`
+	ProductName         string             `json:"product_name,omitempty"`
+	ProductFamilyName   string             `json:"product_family_name,omitempty"`
+	Role                string             `json:"role,omitempty"`
+	SubtotalAmount      string             `json:"subtotal_amount,omitempty"`
+	DiscountAmount      string             `json:"discount_amount,omitempty"`
+	TaxAmount           string             `json:"tax_amount,omitempty"`
+	TotalAmount         string             `json:"total_amount,omitempty"`
+	CreditAmount        string             `json:"credit_amount,omitempty"`
+	PaymentAmount       string             `json:"payment_amount,omitempty"`
+	RefundAmount        string             `json:"refund_amount,omitempty"`
+	PaidAmount          string             `json:"paid_amount,omitempty"`
+	DueAmount           string             `json:"due_amount,omitempty"`
+	PublicURL           string             `json:"public_url,omitempty"`
+	LineItems           []*InvoiceLineItem `json:"line_items,omitempty"`
+}
+
+// Create a proforma invoice for the next renewal of a subscription.
+func CreateProformaInvoice(client Client, subscriptionID int64) (*ProformaInvoice, error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	return createProformaInvoice(client, fmt.Sprintf("subscriptions/%d/proforma_invoices.json", subscriptionID))
+}
+
+// Create a single proforma invoice for every subscription in a group.
+func CreateGroupProformaInvoice(client Client, groupUID string) (*ProformaInvoice, error) {
+	if groupUID == "" {
+		return nil, NoID()
+	}
+	return createProformaInvoice(client, fmt.Sprintf("subscription_groups/%s/proforma_invoices.json", groupUID))
+}
+
+func createProformaInvoice(client Client, uri string) (invoice *ProformaInvoice, err error) {
+	var res *http.Response
+	res, err = client.Post(nil, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	invoice = new(ProformaInvoice)
+	err = json.Unmarshal(body, invoice)
+	return
+}
+
+func GetProformaInvoices(client Client, subscriptionID int64) ([]*ProformaInvoice, error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	return getProformaInvoices(client, fmt.Sprintf("subscriptions/%d/proforma_invoices.json", subscriptionID))
+}
+
+func GetGroupProformaInvoices(client Client, groupUID string) ([]*ProformaInvoice, error) {
+	if groupUID == "" {
+		return nil, NoID()
+	}
+	return getProformaInvoices(client, fmt.Sprintf("subscription_groups/%s/proforma_invoices.json", groupUID))
+}
+
+func getProformaInvoices(client Client, uri string) (invoices []*ProformaInvoice, err error) {
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nestedInvoices := new(struct {
+		ProformaInvoices []*ProformaInvoice `json:"proforma_invoices"`
+	})
+	if err = json.Unmarshal(body, nestedInvoices); err != nil {
+		return
+	}
+	return nestedInvoices.ProformaInvoices, nil
+}
+
+// Preview the proforma invoice of a signup without creating the subscription.
+func (req *SubscriptionRequest) PreviewProformaInvoice(client Client) (invoice *ProformaInvoice, err error) {
+	if req.Request == nil {
+		return nil, errors.New("missing request")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(req.wrap())
+	if err != nil {
+		return
+	}
+	var res *http.Response
+	res, err = client.Post(jsonReq, "subscriptions/proforma_invoices/preview.json")
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nestedInvoice := new(struct {
+		ProformaInvoice *ProformaInvoice `json:"proforma_invoice"`
+	})
+	if err = json.Unmarshal(body, nestedInvoice); err != nil {
+		return
+	}
+	return nestedInvoice.ProformaInvoice, nil
+}
@@ -0,0 +1,184 @@
+package chargify
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestGetGroupProformaInvoices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("subscription_groups/grp_1/proforma_invoices.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"proforma_invoices":[{"uid":"pro_1","total_amount":"100.0","line_items":[{"title":"Basic","quantity":"1.0"}]}]}`))),
+	}, nil)
+	want := []*ProformaInvoice{
+		{
+			UID:         "pro_1",
+			TotalAmount: "100.0",
+			LineItems: []*InvoiceLineItem{
+				{Title: "Basic", Quantity: "1.0"},
+			},
+		},
+	}
+	got, err := GetGroupProformaInvoices(client, "grp_1")
+	if err != nil {
+		t.Fatalf("GetGroupProformaInvoices() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetGroupProformaInvoices() = %v, want %v", got, want)
+	}
+	if _, err = GetGroupProformaInvoices(client, ""); !reflect.DeepEqual(err, NoID()) {
+		t.Errorf("GetGroupProformaInvoices() error = %v, wantErr %v", err, NoID())
+	}
+}
+
+func TestCreateProformaInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	tests := []struct {
+		name         string
+		create       func() (*ProformaInvoice, error)
+		stub         func()
+		wantResponse *ProformaInvoice
+		wantErr      error
+	}{
+		{
+			name: "subscription",
+			create: func() (*ProformaInvoice, error) {
+				return CreateProformaInvoice(client, 1)
+			},
+			stub: func() {
+				client.EXPECT().Post(nil, "subscriptions/1/proforma_invoices.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"uid":"pro_1","subscription_id":1,"status":"draft","total_amount":"100.0"}`))),
+				}, nil)
+			},
+			wantResponse: &ProformaInvoice{UID: "pro_1", SubscriptionID: 1, Status: "draft", TotalAmount: "100.0"},
+		},
+		{
+			name: "group",
+			create: func() (*ProformaInvoice, error) {
+				return CreateGroupProformaInvoice(client, "grp_1")
+			},
+			stub: func() {
+				client.EXPECT().Post(nil, "subscription_groups/grp_1/proforma_invoices.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"uid":"pro_2","consolidation_level":"parent","total_amount":"250.0"}`))),
+				}, nil)
+			},
+			wantResponse: &ProformaInvoice{UID: "pro_2", ConsolidationLevel: "parent", TotalAmount: "250.0"},
+		},
+		{
+			name: "error",
+			create: func() (*ProformaInvoice, error) {
+				return CreateProformaInvoice(client, 1)
+			},
+			stub: func() {
+				client.EXPECT().Post(nil, "subscriptions/1/proforma_invoices.json").Return(&http.Response{
+					StatusCode: 422,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"errors":["mock error"]}`))),
+				}, nil)
+			},
+			wantErr: &Error{Errors: []string{"mock error"}},
+		},
+		{
+			name: "no subscription id",
+			create: func() (*ProformaInvoice, error) {
+				return CreateProformaInvoice(client, 0)
+			},
+			wantErr: NoID(),
+		},
+		{
+			name: "no group uid",
+			create: func() (*ProformaInvoice, error) {
+				return CreateGroupProformaInvoice(client, "")
+			},
+			wantErr: NoID(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := tt.create()
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("CreateProformaInvoice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("CreateProformaInvoice() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestSubscriptionRequest_PreviewProformaInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	tests := []struct {
+		name         string
+		req          *SubscriptionRequest
+		stub         func()
+		wantResponse *ProformaInvoice
+		wantErr      error
+	}{
+		{
+			name: "preview",
+			req:  &SubscriptionRequest{Request: &SubscriptionCreate{ProductHandle: "basic"}},
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"subscription":{"product_handle":"basic"}}`), "subscriptions/proforma_invoices/preview.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"proforma_invoice":{"status":"draft","product_name":"Basic","total_amount":"100.0","line_items":[{"title":"Basic","quantity":"1.0"}]}}`))),
+				}, nil)
+			},
+			wantResponse: &ProformaInvoice{
+				Status:      "draft",
+				ProductName: "Basic",
+				TotalAmount: "100.0",
+				LineItems: []*InvoiceLineItem{
+					{Title: "Basic", Quantity: "1.0"},
+				},
+			},
+		},
+		{
+			name: "error",
+			req:  &SubscriptionRequest{Request: &SubscriptionCreate{ProductHandle: "missing"}},
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"subscription":{"product_handle":"missing"}}`), "subscriptions/proforma_invoices/preview.json").Return(&http.Response{
+					StatusCode: 422,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"errors":["mock error"]}`))),
+				}, nil)
+			},
+			wantErr: &Error{Errors: []string{"mock error"}},
+		},
+		{
+			name:    "no request",
+			req:     &SubscriptionRequest{},
+			wantErr: errors.New("missing request"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := tt.req.PreviewProformaInvoice(client)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("SubscriptionRequest.PreviewProformaInvoice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("SubscriptionRequest.PreviewProformaInvoice() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type ReasonCode struct {
+	ReasonCode *ReasonCodeBody `json:"reason_code"`
+}
+
+type ReasonCodeBody struct {
+	ID          int64  `json:"id,omitempty"`
+	SiteID      int64  `json:"site_id,omitempty"`
+	Code        string `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
+	Position    int64  `json:"position,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+}
+
+const maxReasonCodesPerPage = 200
+
+// how long a ReasonCodeValidator keeps the site's reason codes before fetching them again
+const reasonCodeCacheTTL = 10 * time.Minute
+
+// ReasonCodeValidator checks cancellation reason codes against a cached copy of the site's reason codes.
+// Set it on SubscriptionRequest.ReasonCodes to avoid fetching the codes on every cancellation.
+// Create it with NewReasonCodeValidator. Codes created, updated or deleted are only seen after Invalidate or the cache expiring.
+type ReasonCodeValidator struct {
+	client Client
+	TTL    time.Duration
+
+	mu        sync.Mutex
+	codes     map[string]bool
+	fetchedAt time.Time
+	now       func() time.Time
+}
+
+func NewReasonCodeValidator(client Client) *ReasonCodeValidator {
+	return &ReasonCodeValidator{
+		client: client,
+		TTL:    reasonCodeCacheTTL,
+		now:    time.Now,
+	}
+}
+
+func GetReasonCodes(client Client, pageNumber int32, perPage int32) (codes []*ReasonCode, err error) {
+	uri := fmt.Sprintf("reason_codes.json?per_page=%d&page=%d", perPage, pageNumber)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &codes)
+	return
+}
+
+func GetReasonCode(client Client, reasonCodeID int64) (code *ReasonCode, err error) {
+	if reasonCodeID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("reason_codes/%d.json", reasonCodeID)
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	code = new(ReasonCode)
+	err = json.Unmarshal(body, code)
+	return
+}
+
+func CreateReasonCode(client Client, code *ReasonCode) (response *ReasonCode, err error) {
+	if code == nil || code.ReasonCode == nil || code.ReasonCode.Code == "" {
+		return nil, errors.New("missing request")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(code)
+	if err != nil {
+		return
+	}
+	var res *http.Response
+	res, err = client.Post(jsonReq, "reason_codes.json")
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(ReasonCode)
+	err = json.Unmarshal(body, response)
+	return
+}
+
+func UpdateReasonCode(client Client, reasonCodeID int64, code *ReasonCode) (response *ReasonCode, err error) {
+	if reasonCodeID == 0 {
+		return nil, NoID()
+	}
+	if code == nil || code.ReasonCode == nil {
+		return nil, errors.New("missing request")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(code)
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("reason_codes/%d.json", reasonCodeID)
+	var res *http.Response
+	res, err = client.Put(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(ReasonCode)
+	err = json.Unmarshal(body, response)
+	return
+}
+
+func DeleteReasonCode(client Client, reasonCodeID int64) (err error) {
+	if reasonCodeID == 0 {
+		return NoID()
+	}
+	uri := fmt.Sprintf("reason_codes/%d.json", reasonCodeID)
+	var res *http.Response
+	res, err = client.Delete(nil, uri)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	if err = checkError(res); err != nil {
+		return
+	}
+	return
+}
+
+// Validate returns an error wrapping UnknownReasonCode when code is not one of the site's reason codes,
+// fetching them when the cached list is missing or stale.
+func (v *ReasonCodeValidator) Validate(code string) error {
+	v.mu.Lock()
+	codes := v.codes
+	if codes != nil && v.now().Sub(v.fetchedAt) > v.TTL {
+		codes = nil
+	}
+	v.mu.Unlock()
+	if codes == nil {
+		fetchedAt := v.now()
+		var err error
+		codes, err = fetchReasonCodes(v.client)
+		if err != nil {
+			return err
+		}
+		v.mu.Lock()
+		v.codes = codes
+		v.fetchedAt = fetchedAt
+		v.mu.Unlock()
+	}
+	if !codes[code] {
+		return fmt.Errorf("%w: %s", UnknownReasonCode, code)
+	}
+	return nil
+}
+
+// Invalidate drops the cached reason codes so the next Validate fetches them again.
+func (v *ReasonCodeValidator) Invalidate() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.codes = nil
+}
+
+// reads every page of the site's reason codes
+func fetchReasonCodes(client Client) (map[string]bool, error) {
+	codes := make(map[string]bool)
+	for pageNumber := int32(1); ; pageNumber++ {
+		page, err := GetReasonCodes(client, pageNumber, maxReasonCodesPerPage)
+		if err != nil {
+			return nil, err
+		}
+		for _, rc := range page {
+			if rc.ReasonCode != nil {
+				codes[rc.ReasonCode.Code] = true
+			}
+		}
+		if len(page) < maxReasonCodesPerPage {
+			return codes, nil
+		}
+	}
+}
+
+// checks a cancellation's reason code when the request has a validator, chargify checks it otherwise
+func (req *SubscriptionRequest) validateReasonCode() error {
+	if req.ReasonCodes == nil || req.CancelRequest.ReasonCode == "" {
+		return nil
+	}
+	return req.ReasonCodes.Validate(req.CancelRequest.ReasonCode)
+}
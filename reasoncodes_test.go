@@ -0,0 +1,229 @@
+package chargify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestReasonCodeValidator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	stub := func(codes string) {
+		client.EXPECT().Get("reason_codes.json?per_page=200&page=1").Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(codes))),
+		}, nil)
+	}
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	v := NewReasonCodeValidator(client)
+	v.now = func() time.Time { return now }
+
+	stub(`[{"reason_code":{"id":1,"code":"r1"}}]`)
+	if err := v.Validate("r1"); err != nil {
+		t.Errorf("Validate(r1) error = %v", err)
+	}
+	// cached, no second fetch
+	if err := v.Validate("r2"); !errors.Is(err, UnknownReasonCode) {
+		t.Errorf("Validate(r2) error = %v, want %v", err, UnknownReasonCode)
+	}
+
+	v.Invalidate()
+	stub(`[{"reason_code":{"id":1,"code":"r1"}},{"reason_code":{"id":2,"code":"r2"}}]`)
+	if err := v.Validate("r2"); err != nil {
+		t.Errorf("Validate(r2) after Invalidate error = %v", err)
+	}
+
+	now = now.Add(reasonCodeCacheTTL + time.Second)
+	stub(`[]`)
+	if err := v.Validate("r1"); !errors.Is(err, UnknownReasonCode) {
+		t.Errorf("Validate(r1) after expiry error = %v, want %v", err, UnknownReasonCode)
+	}
+}
+
+func TestReasonCodeValidator_pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	full := make([]string, maxReasonCodesPerPage)
+	for i := range full {
+		full[i] = fmt.Sprintf(`{"reason_code":{"id":%d,"code":"r%d"}}`, i+1, i+1)
+	}
+	gomock.InOrder(
+		client.EXPECT().Get("reason_codes.json?per_page=200&page=1").Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(strings.NewReader("[" + strings.Join(full, ",") + "]")),
+		}, nil),
+		client.EXPECT().Get("reason_codes.json?per_page=200&page=2").Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(strings.NewReader(`[{"reason_code":{"id":201,"code":"late"}}]`)),
+		}, nil),
+	)
+	v := NewReasonCodeValidator(client)
+	if err := v.Validate("late"); err != nil {
+		t.Errorf("Validate(late) error = %v", err)
+	}
+	if err := v.Validate("r1"); err != nil {
+		t.Errorf("Validate(r1) error = %v", err)
+	}
+}
+
+func TestCreateReasonCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	tests := []struct {
+		name         string
+		code         *ReasonCode
+		stub         func()
+		wantResponse *ReasonCode
+		wantErr      error
+	}{
+		{
+			name: "create",
+			code: &ReasonCode{ReasonCode: &ReasonCodeBody{Code: "too_expensive", Description: "Too expensive"}},
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"reason_code":{"code":"too_expensive","description":"Too expensive"}}`), "reason_codes.json").Return(&http.Response{
+					StatusCode: 201,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"reason_code":{"id":3,"code":"too_expensive","description":"Too expensive","position":1}}`))),
+				}, nil)
+			},
+			wantResponse: &ReasonCode{ReasonCode: &ReasonCodeBody{ID: 3, Code: "too_expensive", Description: "Too expensive", Position: 1}},
+		},
+		{
+			name: "error",
+			code: &ReasonCode{ReasonCode: &ReasonCodeBody{Code: "too_expensive"}},
+			stub: func() {
+				client.EXPECT().Post([]byte(`{"reason_code":{"code":"too_expensive"}}`), "reason_codes.json").Return(&http.Response{
+					StatusCode: 422,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"errors":["mock error"]}`))),
+				}, nil)
+			},
+			wantErr: &Error{Errors: []string{"mock error"}},
+		},
+		{
+			name:    "no code",
+			code:    &ReasonCode{ReasonCode: &ReasonCodeBody{Description: "Too expensive"}},
+			wantErr: errors.New("missing request"),
+		},
+		{
+			name:    "no request",
+			wantErr: errors.New("missing request"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := CreateReasonCode(client, tt.code)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("CreateReasonCode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("CreateReasonCode() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestUpdateReasonCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	tests := []struct {
+		name         string
+		reasonCodeID int64
+		code         *ReasonCode
+		stub         func()
+		wantResponse *ReasonCode
+		wantErr      error
+	}{
+		{
+			name:         "update",
+			reasonCodeID: 3,
+			code:         &ReasonCode{ReasonCode: &ReasonCodeBody{Description: "Price too high"}},
+			stub: func() {
+				client.EXPECT().Put([]byte(`{"reason_code":{"description":"Price too high"}}`), "reason_codes/3.json").Return(&http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"reason_code":{"id":3,"code":"too_expensive","description":"Price too high"}}`))),
+				}, nil)
+			},
+			wantResponse: &ReasonCode{ReasonCode: &ReasonCodeBody{ID: 3, Code: "too_expensive", Description: "Price too high"}},
+		},
+		{
+			name:    "no id",
+			code:    &ReasonCode{ReasonCode: &ReasonCodeBody{Description: "Price too high"}},
+			wantErr: NoID(),
+		},
+		{
+			name:         "no request",
+			reasonCodeID: 3,
+			wantErr:      errors.New("missing request"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := UpdateReasonCode(client, tt.reasonCodeID, tt.code)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("UpdateReasonCode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("UpdateReasonCode() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestGetReasonCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Get("reason_codes/3.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"reason_code":{"id":3,"code":"too_expensive"}}`))),
+	}, nil)
+	want := &ReasonCode{ReasonCode: &ReasonCodeBody{ID: 3, Code: "too_expensive"}}
+	got, err := GetReasonCode(client, 3)
+	if err != nil {
+		t.Fatalf("GetReasonCode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetReasonCode() = %v, want %v", got, want)
+	}
+	if _, err = GetReasonCode(client, 0); !reflect.DeepEqual(err, NoID()) {
+		t.Errorf("GetReasonCode() error = %v, wantErr %v", err, NoID())
+	}
+}
+
+func TestDeleteReasonCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	client.EXPECT().Delete(nil, "reason_codes/3.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"ok":"ok"}`))),
+	}, nil)
+	client.EXPECT().Delete(nil, "reason_codes/4.json").Return(&http.Response{
+		StatusCode: 404,
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}, nil)
+	if err := DeleteReasonCode(client, 3); err != nil {
+		t.Errorf("DeleteReasonCode() error = %v", err)
+	}
+	if err := DeleteReasonCode(client, 4); err != NotFound {
+		t.Errorf("DeleteReasonCode() error = %v, wantErr %v", err, NotFound)
+	}
+	if err := DeleteReasonCode(client, 0); !reflect.DeepEqual(err, NoID()) {
+		t.Errorf("DeleteReasonCode() error = %v, wantErr %v", err, NoID())
+	}
+}
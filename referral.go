@@ -0,0 +1,68 @@
+package chargify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+type ReferralCode struct {
+	ID             int64  `json:"id,omitempty"`
+	SiteID         int64  `json:"site_id,omitempty"`
+	SubscriptionID int64  `json:"subscription_id,omitempty"`
+	Code           string `json:"code,omitempty"`
+}
+
+// ReferralCodeNotFoundError is returned when a referral code does not exist on the site.
+// It matches NotFound with errors.Is.
+type ReferralCodeNotFoundError struct {
+	Code string
+}
+
+func (e *ReferralCodeNotFoundError) Error() string {
+	return fmt.Sprintf("referral code %s not found", e.Code)
+}
+
+func (e *ReferralCodeNotFoundError) Is(target error) bool {
+	return target == NotFound
+}
+
+// Validate a referral code and return the subscription it belongs to, so a signup can be rejected before
+// SubscriptionRequest.Create.
+func ValidateReferralCode(client Client, code string) (response *SubscriptionResponse, err error) {
+	if code == "" {
+		return nil, errors.New("no referral code provided")
+	}
+	uri := fmt.Sprintf("referral_codes/validate.json?code=%s", url.QueryEscape(code))
+	var res *http.Response
+	res, err = client.Get(uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		if err == NotFound {
+			res.Body.Close()
+			err = &ReferralCodeNotFoundError{Code: code}
+		}
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	nested := new(struct {
+		ReferralCode *ReferralCode `json:"referral_code"`
+	})
+	if err = json.Unmarshal(body, nested); err != nil {
+		return
+	}
+	if nested.ReferralCode == nil || nested.ReferralCode.SubscriptionID == 0 {
+		return nil, &ReferralCodeNotFoundError{Code: code}
+	}
+	return GetSubscription(client, nested.ReferralCode.SubscriptionID)
+}
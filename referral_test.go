@@ -0,0 +1,83 @@
+package chargify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestValidateReferralCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	res := &SubscriptionResponse{
+		ID:           123456789,
+		ReferralCode: "abc123",
+	}
+	body, err := json.Marshal(res.wrap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		code         string
+		stub         func()
+		wantResponse *SubscriptionResponse
+		wantErr      error
+	}{
+		{
+			name: "valid",
+			code: "abc123",
+			stub: func() {
+				client.EXPECT().Get("referral_codes/validate.json?code=abc123").Return(&http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"referral_code":{"id":1,"subscription_id":123456789,"code":"abc123"}}`))),
+				}, nil)
+				client.EXPECT().Get("subscriptions/123456789.json").Return(&http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewReader(body)),
+				}, nil)
+			},
+			wantResponse: res,
+		},
+		{
+			name: "not found",
+			code: "a b",
+			stub: func() {
+				client.EXPECT().Get("referral_codes/validate.json?code=a+b").Return(&http.Response{
+					StatusCode: 404,
+					Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+				}, nil)
+			},
+			wantErr: &ReferralCodeNotFoundError{Code: "a b"},
+		},
+		{
+			name:    "no code",
+			wantErr: errors.New("no referral code provided"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := ValidateReferralCode(client, tt.code)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ValidateReferralCode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && tt.stub != nil && !errors.Is(err, NotFound) {
+				t.Errorf("ValidateReferralCode() error = %v, want it to match NotFound", err)
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("ValidateReferralCode() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
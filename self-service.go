@@ -2,16 +2,59 @@ package chargify
 
 import (
 	"crypto/sha1"
+	"crypto/subtle"
+	"errors"
 	"fmt"
-	"log"
 )
 
+// Self-service page types, used as the first path segment of the page URL.
+const (
+	SelfServiceUpdatePayment = "update_payment"
+	SelfServiceReceipt       = "receipt"
+	SelfServiceInvoice       = "invoice"
+)
+
+// chargify only puts the first 10 characters of the hash in page URLs
+const selfServiceTokenLength = 10
+
+var NoSiteSharedKey = errors.New("no site shared key configured")
+
 func (c *client) GenerateSelfServiceLink(method string, subscriptionID int64) string {
 	if c.siteSharedKey == "" {
 		return ""
 	}
-	toHash := fmt.Sprintf("%s--%d--%s", method, subscriptionID, c.siteSharedKey)
-	log.Printf(toHash)
-	hash := sha1.Sum([]byte(toHash))
+	return selfServiceToken(method, subscriptionID, c.siteSharedKey)
+}
+
+// SelfServiceURL builds the full URL of a self-service page for a subscription on the client's site.
+func (c *client) SelfServiceURL(page string, subscriptionID int64) (string, error) {
+	if c.siteSharedKey == "" {
+		return "", NoSiteSharedKey
+	}
+	if page == "" {
+		return "", errors.New("no page provided")
+	}
+	if subscriptionID == 0 {
+		return "", NoID()
+	}
+	token := selfServiceToken(page, subscriptionID, c.siteSharedKey)[:selfServiceTokenLength]
+	return fmt.Sprintf("%s/%s/%d/%s", c.url, page, subscriptionID, token), nil
+}
+
+// VerifySelfServiceToken checks a token coming back in a return URL, either the short URL form or the full hash.
+func (c *client) VerifySelfServiceToken(page string, subscriptionID int64, token string) bool {
+	if c.siteSharedKey == "" || token == "" {
+		return false
+	}
+	want := selfServiceToken(page, subscriptionID, c.siteSharedKey)
+	if len(token) == selfServiceTokenLength {
+		want = want[:selfServiceTokenLength]
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1
+}
+
+// the input contains the site shared key so it must never be logged
+func selfServiceToken(page string, subscriptionID int64, siteSharedKey string) string {
+	hash := sha1.Sum([]byte(fmt.Sprintf("%s--%d--%s", page, subscriptionID, siteSharedKey)))
 	return fmt.Sprintf("%x", hash)
 }
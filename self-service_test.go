@@ -0,0 +1,51 @@
+package chargify
+
+import (
+	"testing"
+)
+
+func TestClient_SelfServiceURL(t *testing.T) {
+	c := &client{
+		url:           constructUrl("acme"),
+		siteSharedKey: "mock-shared-key",
+	}
+	got, err := c.SelfServiceURL(SelfServiceUpdatePayment, 123)
+	if err != nil {
+		t.Fatalf("client.SelfServiceURL() error = %v", err)
+	}
+	token := selfServiceToken(SelfServiceUpdatePayment, 123, "mock-shared-key")
+	want := "https://acme.chargify.com/update_payment/123/" + token[:10]
+	if got != want {
+		t.Errorf("client.SelfServiceURL() = %v, want %v", got, want)
+	}
+	if _, err = (&client{}).SelfServiceURL(SelfServiceUpdatePayment, 123); err != NoSiteSharedKey {
+		t.Errorf("client.SelfServiceURL() error = %v, wantErr %v", err, NoSiteSharedKey)
+	}
+}
+
+func TestClient_VerifySelfServiceToken(t *testing.T) {
+	c := &client{
+		siteSharedKey: "mock-shared-key",
+	}
+	token := selfServiceToken(SelfServiceReceipt, 123, "mock-shared-key")
+	tests := []struct {
+		name           string
+		page           string
+		subscriptionID int64
+		token          string
+		want           bool
+	}{
+		{"short", SelfServiceReceipt, 123, token[:10], true},
+		{"full", SelfServiceReceipt, 123, token, true},
+		{"other subscription", SelfServiceReceipt, 124, token[:10], false},
+		{"other page", SelfServiceUpdatePayment, 123, token[:10], false},
+		{"empty", SelfServiceReceipt, 123, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.VerifySelfServiceToken(tt.page, tt.subscriptionID, tt.token); got != tt.want {
+				t.Errorf("client.VerifySelfServiceToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
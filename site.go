@@ -0,0 +1,147 @@
+package chargify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+type SiteStats struct {
+	SellerName   string     `json:"seller_name,omitempty"`
+	SiteName     string     `json:"site_name,omitempty"`
+	SiteID       int64      `json:"site_id,omitempty"`
+	SiteCurrency string     `json:"site_currency,omitempty"`
+	Stats        *StatsBody `json:"stats,omitempty"`
+}
+
+// Revenue values are formatted amounts in the site currency, e.g. "$1,024.00"
+type StatsBody struct {
+	TotalSubscriptions         int64  `json:"total_subscriptions"`
+	SubscriptionsToday         int64  `json:"subscriptions_today"`
+	TotalRevenue               string `json:"total_revenue,omitempty"`
+	RevenueToday               string `json:"revenue_today,omitempty"`
+	RevenueThisMonth           string `json:"revenue_this_month,omitempty"`
+	RevenueThisYear            string `json:"revenue_this_year,omitempty"`
+	TotalCanceledSubscriptions int64  `json:"total_canceled_subscriptions"`
+	TotalActiveSubscriptions   int64  `json:"total_active_subscriptions"`
+	TotalPastDueSubscriptions  int64  `json:"total_past_due_subscriptions"`
+	TotalUnpaidSubscriptions   int64  `json:"total_unpaid_subscriptions"`
+	TotalDunningSubscriptions  int64  `json:"total_dunning_subscriptions"`
+}
+
+type Site struct {
+	Site *SiteBody `json:"site"`
+}
+
+type SiteBody struct {
+	ID                             int64               `json:"id,omitempty"`
+	Name                           string              `json:"name,omitempty"`
+	Subdomain                      string              `json:"subdomain,omitempty"`
+	Currency                       string              `json:"currency,omitempty"`
+	SellerID                       int64               `json:"seller_id,omitempty"`
+	NonPrimaryCurrencies           []string            `json:"non_primary_currencies,omitempty"`
+	RelationshipInvoicingEnabled   bool                `json:"relationship_invoicing_enabled,omitempty"`
+	CustomerHierarchyEnabled       bool                `json:"customer_hierarchy_enabled,omitempty"`
+	WhopaysEnabled                 bool                `json:"whopays_enabled,omitempty"`
+	WhopaysDefaultPayer            string              `json:"whopays_default_payer,omitempty"`
+	DefaultPaymentCollectionMethod string              `json:"default_payment_collection_method,omitempty"`
+	Test                           bool                `json:"test,omitempty"`
+	TaxConfiguration               *TaxConfiguration   `json:"tax_configuration,omitempty"`
+	NetTerms                       *NetTerms           `json:"net_terms,omitempty"`
+	AllocationSettings             *AllocationSettings `json:"allocation_settings,omitempty"`
+}
+
+type TaxConfiguration struct {
+	Kind               string `json:"kind,omitempty"`
+	DestinationAddress string `json:"destination_address,omitempty"`
+	FullyIntegrated    bool   `json:"fully_integrated,omitempty"`
+}
+
+type NetTerms struct {
+	DefaultNetTerms                    int64 `json:"default_net_terms"`
+	AutomaticNetTerms                  int64 `json:"automatic_net_terms"`
+	RemittanceNetTerms                 int64 `json:"remittance_net_terms"`
+	NetTermsOnRemittanceSignupsEnabled bool  `json:"net_terms_on_remittance_signups_enabled,omitempty"`
+	CustomNetTermsEnabled              bool  `json:"custom_net_terms_enabled,omitempty"`
+}
+
+// Defaults applied to component allocations that do not set their own charge or credit behaviour.
+type AllocationSettings struct {
+	UpgradeCharge   string `json:"upgrade_charge,omitempty"`
+	DowngradeCredit string `json:"downgrade_credit,omitempty"`
+	AccrueCharge    string `json:"accrue_charge,omitempty"`
+}
+
+// SiteMismatchError is returned by CheckSite when the client is pointed at a different site than expected.
+type SiteMismatchError struct {
+	Field    string
+	Expected string
+	Actual   string
+}
+
+func (e *SiteMismatchError) Error() string {
+	return fmt.Sprintf("site %s is %s, expected %s", e.Field, e.Actual, e.Expected)
+}
+
+// Get the site's subscription counts and revenue totals.
+func GetSiteStats(client Client) (stats *SiteStats, err error) {
+	var res *http.Response
+	res, err = client.Get("stats.json")
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	stats = new(SiteStats)
+	err = json.Unmarshal(body, stats)
+	return
+}
+
+// Get the site configuration: currency, tax, net terms and allocation defaults.
+func GetSite(client Client) (site *Site, err error) {
+	var res *http.Response
+	res, err = client.Get("site.json")
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	site = new(Site)
+	err = json.Unmarshal(body, site)
+	return
+}
+
+// Confirm the client is pointed at the expected site, e.g. on startup. An empty currency is not checked.
+// Production sites can be told apart from test sites with SiteBody.Test on the returned site.
+func CheckSite(client Client, subdomain string, currency string) (*SiteBody, error) {
+	site, err := GetSite(client)
+	if err != nil {
+		return nil, err
+	}
+	if site.Site == nil {
+		return nil, NotFound
+	}
+	if !strings.EqualFold(site.Site.Subdomain, subdomain) {
+		return site.Site, &SiteMismatchError{Field: "subdomain", Expected: subdomain, Actual: site.Site.Subdomain}
+	}
+	if currency != "" && !strings.EqualFold(site.Site.Currency, currency) {
+		return site.Site, &SiteMismatchError{Field: "currency", Expected: currency, Actual: site.Site.Currency}
+	}
+	return site.Site, nil
+}
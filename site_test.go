@@ -0,0 +1,68 @@
+package chargify
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify/test"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCheckSite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	site := &SiteBody{
+		ID:        1,
+		Subdomain: "acme",
+		Currency:  "USD",
+	}
+	stub := func() {
+		client.EXPECT().Get("site.json").Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"site":{"id":1,"subdomain":"acme","currency":"USD"}}`))),
+		}, nil)
+	}
+	tests := []struct {
+		name      string
+		subdomain string
+		currency  string
+		wantErr   error
+	}{
+		{
+			name:      "match",
+			subdomain: "acme",
+			currency:  "usd",
+		},
+		{
+			name:      "currency not checked",
+			subdomain: "ACME",
+		},
+		{
+			name:      "wrong subdomain",
+			subdomain: "acme-staging",
+			wantErr:   &SiteMismatchError{Field: "subdomain", Expected: "acme-staging", Actual: "acme"},
+		},
+		{
+			name:      "wrong currency",
+			subdomain: "acme",
+			currency:  "EUR",
+			wantErr:   &SiteMismatchError{Field: "currency", Expected: "EUR", Actual: "USD"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub()
+			got, err := CheckSite(client, tt.subdomain, tt.currency)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("CheckSite() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, site) {
+				t.Errorf("CheckSite() = %v, want %v", got, site)
+			}
+		})
+	}
+}
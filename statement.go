@@ -93,11 +93,13 @@ type Events struct {
 }
 
 type Event struct {
-	Id             int64  `json:"id,omitempty"`
-	Key            string `json:"key,omitempty"`
-	Message        string `json:"message,omitempty"`
-	SubscriptionId int64  `json:"subscription_id,omitempty"`
-	CreatedAt      string `json:"created_at,omitempty"`
+	Id                int64           `json:"id,omitempty"`
+	Key               string          `json:"key,omitempty"`
+	Message           string          `json:"message,omitempty"`
+	SubscriptionId    int64           `json:"subscription_id,omitempty"`
+	CustomerId        int64           `json:"customer_id,omitempty"`
+	CreatedAt         string          `json:"created_at,omitempty"`
+	EventSpecificData json.RawMessage `json:"event_specific_data,omitempty"`
 }
 
 func GetSubscriptionStatements(client Client, subscriptionId int64, pageNumber int32, perPage int32) ([]*Statement, error) {
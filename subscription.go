@@ -7,19 +7,44 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	SubscriptionStateActive         = "active"
+	SubscriptionStateTrialing       = "trialing"
+	SubscriptionStatePastDue        = "past_due"
+	SubscriptionStateUnpaid         = "unpaid"
+	SubscriptionStateOnHold         = "on_hold"
+	SubscriptionStateCanceled       = "canceled"
+	SubscriptionStateExpired        = "expired"
+	SubscriptionStateTrialEnded     = "trial_ended"
+	SubscriptionStateAwaitingSignup = "awaiting_signup"
+	SubscriptionStateSuspended      = "suspended"
+	SubscriptionStateAssessing      = "assessing"
+	SubscriptionStatePending        = "pending"
+	SubscriptionStateSoftFailure    = "soft_failure"
+	SubscriptionStateFailedToCreate = "failed_to_create"
+	SubscriptionStatePaused         = "paused"
 )
 
 type SubscriptionRequest struct {
 	Request       *SubscriptionCreate
 	CancelRequest *SubscriptionCancel
+	// ReasonCodes validates CancelRequest.ReasonCode before cancelling. When nil the code is not checked up front
+	// and an unknown code is only reported by chargify.
+	ReasonCodes *ReasonCodeValidator
 }
 
 type SubscriptionCreate struct {
 	ProductHandle                 string                   `json:"product_handle,omitempty"`
 	ProductID                     string                   `json:"product_id,omitempty"`
 	ProductPricePointHandle       string                   `json:"product_price_point_handle,omitempty"`
+	OfferID                       int64                    `json:"offer_id,omitempty"`
 	Ref                           string                   `json:"ref,omitempty"`
 	CouponCode                    string                   `json:"coupon_code,omitempty"`
+	ReferralCode                  string                   `json:"referral_code,omitempty"`
 	PaymentCollectionMethod       string                   `json:"payment_collection_method,omitempty"`
 	ReceivesInvoiceEmails         bool                     `json:"receives_invoice_emails,omitempty"`
 	NetTerms                      string                   `json:"net_terms,omitempty"`
@@ -33,6 +58,7 @@ type SubscriptionCreate struct {
 	Components                    []*SubscriptionComponent `json:"components,omitempty"`
 	CalendarBilling               *CalendarBilling         `json:"calendar_billing,omitempty"`
 	Metafields                    *SubscriptionMetafields  `json:"metafields,omitempty"`
+	Group                         *GroupMembership         `json:"group,omitempty"`
 }
 
 type SubscriptionResponse struct {
@@ -58,6 +84,9 @@ type SubscriptionResponse struct {
 	SignupPaymentID               int64         `json:"signup_payment_id,omitempty"`
 	SignupRevenue                 string        `json:"signup_revenue,omitempty"`
 	DelayedCancelAt               string        `json:"delayed_cancel_at,omitempty"`
+	ScheduledCancellationAt       string        `json:"scheduled_cancellation_at,omitempty"`
+	OnHoldAt                      string        `json:"on_hold_at,omitempty"`
+	AutomaticallyResumeAt         string        `json:"automatically_resume_at,omitempty"`
 	CouponCode                    string        `json:"coupon_code,omitempty"`
 	PaymentCollectionMethod       string        `json:"payment_collection_method,omitempty"`
 	SnapDay                       string        `json:"snap_day,omitempty"`
@@ -73,6 +102,7 @@ type SubscriptionResponse struct {
 	CouponUseCount                int64         `json:"coupon_use_count,omitempty"`
 	CouponUsesAllowed             int64         `json:"coupon_uses_allowed,omitempty"`
 	NextProductHandle             string        `json:"next_product_handle,omitempty"`
+	NextProductPricePointID       int64         `json:"next_product_price_point_id,omitempty"`
 	StoredCredentialTransactionID int64         `json:"stored_credential_transaction_id,omitempty"`
 }
 
@@ -81,6 +111,24 @@ type SubscriptionCancel struct {
 	CancellationMessage string `json:"cancellation_message,omitempty"`
 	CancellationMethod  string `json:"cancellation_method,omitempty"`
 	ReasonCode          string `json:"reason_code,omitempty"`
+	// ScheduledCancellationAt is only used by CancelDelayed
+	ScheduledCancellationAt string `json:"scheduled_cancellation_at,omitempty"`
+}
+
+type ProductChange struct {
+	ProductID               int64  `json:"product_id,omitempty"`
+	ProductHandle           string `json:"product_handle,omitempty"`
+	ProductPricePointID     int64  `json:"product_price_point_id,omitempty"`
+	ProductPricePointHandle string `json:"product_price_point_handle,omitempty"`
+	ProductChangeDelayed    bool   `json:"product_change_delayed,omitempty"`
+}
+
+type SubscriptionOverride struct {
+	ActivatedAt           string `json:"activated_at,omitempty"`
+	CanceledAt            string `json:"canceled_at,omitempty"`
+	CancellationMessage   string `json:"cancellation_message,omitempty"`
+	ExpiresOn             string `json:"expires_on,omitempty"`
+	CurrentPeriodStartsAt string `json:"current_period_starts_at,omitempty"`
 }
 
 type PublicSignupPage struct {
@@ -144,6 +192,10 @@ func (req *SubscriptionRequest) Create(client Client) (response *SubscriptionRes
 	if req.Request == nil {
 		return nil, errors.New("missing request")
 	}
+	// an offer already decides the product
+	if req.Request.OfferID != 0 && (req.Request.ProductID != "" || req.Request.ProductHandle != "") {
+		return nil, errors.New("cannot set a product when signing up with an offer")
+	}
 	// have to nest this because chargify is a mess
 	var jsonReq []byte
 	jsonReq, err = json.Marshal(req.wrap())
@@ -226,21 +278,67 @@ func GetSubscription(client Client, subscriptionID int64) (response *Subscriptio
 	return
 }
 
-func (req *SubscriptionRequest) CancelDelayed(client Client) (err error) {
+// Cancel a subscription at the end of its current period, or at CancelRequest.ScheduledCancellationAt when set.
+// The delayed cancel endpoint does not return the subscription so it is fetched again afterwards.
+func (req *SubscriptionRequest) CancelDelayed(client Client) (response *SubscriptionResponse, err error) {
 	if req.CancelRequest == nil {
-		return errors.New("missing request")
+		return nil, errors.New("missing request")
 	}
 	if req.CancelRequest.SubscriptionID == "" {
-		return NoID()
+		return nil, NoID()
+	}
+	var subscriptionID int64
+	subscriptionID, err = strconv.ParseInt(req.CancelRequest.SubscriptionID, 10, 64)
+	if err != nil {
+		return
+	}
+	if req.CancelRequest.ScheduledCancellationAt != "" {
+		var cancelAt time.Time
+		cancelAt, err = parseDate(req.CancelRequest.ScheduledCancellationAt)
+		if err != nil {
+			return
+		}
+		if !cancelAt.After(time.Now()) {
+			return nil, errors.New("scheduled cancellation must be in the future")
+		}
+	}
+	if err = req.validateReasonCode(); err != nil {
+		return
 	}
 	var jsonReq []byte
 	jsonReq, err = json.Marshal(req.wrap())
 	if err != nil {
 		return
 	}
-	uri := fmt.Sprintf("subscriptions/%s/delayed_cancel.json", req.CancelRequest.SubscriptionID)
-	_, err = client.Post(jsonReq, uri)
-	return
+	uri := fmt.Sprintf("subscriptions/%d/delayed_cancel.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Post(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	res.Body.Close()
+	return GetSubscription(client, subscriptionID)
+}
+
+// Remove a pending delayed cancellation so the subscription renews as normal.
+func StopDelayedCancel(client Client, subscriptionID int64) (response *SubscriptionResponse, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	uri := fmt.Sprintf("subscriptions/%d/delayed_cancel.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Delete(nil, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	res.Body.Close()
+	return GetSubscription(client, subscriptionID)
 }
 
 func (req *SubscriptionRequest) CancelNow(client Client) (response *SubscriptionResponse, err error) {
@@ -250,6 +348,9 @@ func (req *SubscriptionRequest) CancelNow(client Client) (response *Subscription
 	if req.CancelRequest.SubscriptionID == "" {
 		return nil, NoID()
 	}
+	if err = req.validateReasonCode(); err != nil {
+		return
+	}
 	var jsonReq []byte
 	jsonReq, err = json.Marshal(req.wrap())
 	if err != nil {
@@ -324,6 +425,193 @@ func ResumeSubscription(client Client, subscriptionID int64) (response *Subscrip
 	return
 }
 
+// Schedule a product change to take effect at the end of the current billing period.
+// Use Migration.Create to switch products immediately instead.
+func ScheduleProductChange(client Client, subscriptionID int64, change *ProductChange) (response *SubscriptionResponse, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	if change == nil {
+		return nil, errors.New("missing request")
+	}
+	if change.ProductID == 0 && change.ProductHandle == "" {
+		return nil, errors.New("no product id or handle provided")
+	}
+	// the caller's change is left as it was
+	delayed := *change
+	delayed.ProductChangeDelayed = true
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		Subscription *ProductChange `json:"subscription"`
+	}{
+		Subscription: &delayed,
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Put(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(SubscriptionResponse)
+	err = json.Unmarshal(body, response.wrap())
+	return
+}
+
+// Cancel a product change previously scheduled with ScheduleProductChange.
+func CancelProductChange(client Client, subscriptionID int64) (response *SubscriptionResponse, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	// chargify clears the pending change when next_product_id is sent blank
+	jsonReq := []byte(`{"subscription":{"next_product_id":""}}`)
+	uri := fmt.Sprintf("subscriptions/%d.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Put(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(SubscriptionResponse)
+	err = json.Unmarshal(body, response.wrap())
+	return
+}
+
+// Override the historical dates of a subscription, e.g. when importing from another billing system.
+// The override endpoint does not return the subscription so it is fetched again afterwards.
+func OverrideSubscription(client Client, subscriptionID int64, override *SubscriptionOverride) (response *SubscriptionResponse, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	if override == nil {
+		return nil, errors.New("missing request")
+	}
+	if err = override.validate(); err != nil {
+		return
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		Subscription *SubscriptionOverride `json:"subscription"`
+	}{
+		Subscription: override,
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d/override.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Put(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	res.Body.Close()
+	return GetSubscription(client, subscriptionID)
+}
+
+// Move the next billing date of a subscription. nextBillingAt must be in the future.
+func ChangeNextBillingDate(client Client, subscriptionID int64, nextBillingAt string) (response *SubscriptionResponse, err error) {
+	if subscriptionID == 0 {
+		return nil, NoID()
+	}
+	var next time.Time
+	next, err = parseDate(nextBillingAt)
+	if err != nil {
+		return
+	}
+	if !next.After(time.Now()) {
+		return nil, errors.New("next billing date must be in the future")
+	}
+	var jsonReq []byte
+	jsonReq, err = json.Marshal(&struct {
+		Subscription *SubscriptionCreate `json:"subscription"`
+	}{
+		Subscription: &SubscriptionCreate{
+			NextBillingAt: nextBillingAt,
+		},
+	})
+	if err != nil {
+		return
+	}
+	uri := fmt.Sprintf("subscriptions/%d.json", subscriptionID)
+	var res *http.Response
+	res, err = client.Put(jsonReq, uri)
+	if err != nil {
+		return
+	}
+	if err = checkError(res); err != nil {
+		return
+	}
+	defer res.Body.Close()
+	var body []byte
+	body, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+	response = new(SubscriptionResponse)
+	err = json.Unmarshal(body, response.wrap())
+	return
+}
+
+// checks that every date parses and that none of them come before activation
+func (o *SubscriptionOverride) validate() error {
+	// checked in this order so the same field is always reported first
+	dates := []struct {
+		name string
+		date string
+	}{
+		{"activated_at", o.ActivatedAt},
+		{"canceled_at", o.CanceledAt},
+		{"expires_on", o.ExpiresOn},
+		{"current_period_starts_at", o.CurrentPeriodStartsAt},
+	}
+	parsed := make(map[string]time.Time)
+	for _, d := range dates {
+		if d.date == "" {
+			continue
+		}
+		t, err := parseDate(d.date)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", d.name, err)
+		}
+		parsed[d.name] = t
+	}
+	if len(parsed) == 0 {
+		return errors.New("no dates to override")
+	}
+	activatedAt, ok := parsed["activated_at"]
+	if !ok {
+		return nil
+	}
+	for _, d := range dates[1:] {
+		if t, ok := parsed[d.name]; ok && t.Before(activatedAt) {
+			return fmt.Errorf("%s cannot be before activated_at", d.name)
+		}
+	}
+	return nil
+}
+
 func (req *SubscriptionRequest) wrap() interface{} {
 	if req.Request != nil {
 		return &struct {
@@ -349,3 +637,11 @@ func (res *SubscriptionResponse) wrap() interface{} {
 		Subscription: res,
 	}
 }
+
+// chargify accepts either full timestamps or plain dates
+func parseDate(date string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", date)
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"reflect"
@@ -50,6 +51,13 @@ func TestSubscriptionRequest_Create(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	offerRes := &SubscriptionResponse{
+		ID: 987654321,
+	}
+	offerBody, err := json.Marshal(offerRes.wrap())
+	if err != nil {
+		t.Fatal(err)
+	}
 	tests := []struct {
 		name         string
 		fields       fields
@@ -73,6 +81,35 @@ func TestSubscriptionRequest_Create(t *testing.T) {
 			},
 			wantResponse: res,
 		},
+		{
+			name: "create with offer",
+			fields: fields{
+				Request: &SubscriptionCreate{
+					CustomerID: "9",
+					OfferID:    3,
+				},
+			},
+			args: args{
+				client: client,
+				stub: func() {
+					client.EXPECT().Post([]byte(`{"subscription":{"offer_id":3,"customer_id":"9"}}`), "subscriptions.json").Return(&http.Response{
+						StatusCode: 201,
+						Body:       ioutil.NopCloser(bytes.NewReader(offerBody)),
+					}, nil)
+				},
+			},
+			wantResponse: offerRes,
+		},
+		{
+			name: "create offer and product",
+			fields: fields{
+				Request: &SubscriptionCreate{
+					ProductHandle: "basic",
+					OfferID:       3,
+				},
+			},
+			wantErr: errors.New("cannot set a product when signing up with an offer"),
+		},
 		{
 			name:    "create no req",
 			wantErr: errors.New("missing request"),
@@ -345,6 +382,7 @@ func TestSubscriptionRequest_CancelDelayed(t *testing.T) {
 	type fields struct {
 		Request       *SubscriptionCreate
 		CancelRequest *SubscriptionCancel
+		ReasonCodes   *ReasonCodeValidator
 	}
 	type args struct {
 		client Client
@@ -352,11 +390,20 @@ func TestSubscriptionRequest_CancelDelayed(t *testing.T) {
 	}
 	ctrl := gomock.NewController(t)
 	client := test.NewMockClient(ctrl)
+	res := &SubscriptionResponse{
+		ID:              123456789,
+		DelayedCancelAt: "2021-01-01T00:00:00Z",
+	}
+	body, err := json.Marshal(res.wrap())
+	if err != nil {
+		t.Fatal(err)
+	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr error
+		name         string
+		fields       fields
+		args         args
+		wantResponse *SubscriptionResponse
+		wantErr      error
 	}{
 		{
 			name: "cancel delayed",
@@ -366,13 +413,76 @@ func TestSubscriptionRequest_CancelDelayed(t *testing.T) {
 					ReasonCode:          "r1",
 					CancellationMessage: "GOOD DAY SIR",
 				},
+				ReasonCodes: NewReasonCodeValidator(client),
+			},
+			args: args{
+				client: client,
+				stub: func() {
+					client.EXPECT().Get("reason_codes.json?per_page=200&page=1").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"reason_code":{"id":1,"code":"r1"}}]`))),
+					}, nil)
+					client.EXPECT().Post(gomock.Any(), "subscriptions/123456789/delayed_cancel.json").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message":"ok"}`))),
+					}, nil)
+					client.EXPECT().Get("subscriptions/123456789.json").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader(body)),
+					}, nil)
+				},
+			},
+			wantResponse: res,
+		},
+		{
+			name: "cancel delayed error",
+			fields: fields{
+				CancelRequest: &SubscriptionCancel{
+					SubscriptionID: "123456789",
+				},
+			},
+			args: args{
+				client: client,
+				stub: func() {
+					client.EXPECT().Post(gomock.Any(), "subscriptions/123456789/delayed_cancel.json").Return(&http.Response{
+						StatusCode: 422,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"errors":["mock error"]}`))),
+					}, nil)
+				},
+			},
+			wantErr: &Error{
+				Errors: []string{"mock error"},
+			},
+		},
+		{
+			name: "cancel delayed unknown reason code",
+			fields: fields{
+				CancelRequest: &SubscriptionCancel{
+					SubscriptionID: "123456789",
+					ReasonCode:     "r2",
+				},
+				ReasonCodes: NewReasonCodeValidator(client),
 			},
 			args: args{
 				client: client,
 				stub: func() {
-					client.EXPECT().Post(gomock.Any(), "subscriptions/123456789/delayed_cancel.json").Return(nil, nil)
+					client.EXPECT().Get("reason_codes.json?per_page=200&page=1").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte(`[{"reason_code":{"id":1,"code":"r1"}}]`))),
+					}, nil)
 				},
 			},
+			wantErr: fmt.Errorf("%w: %s", UnknownReasonCode, "r2"),
+		},
+		{
+			name: "cancel delayed in the past",
+			fields: fields{
+				CancelRequest: &SubscriptionCancel{
+					SubscriptionID:          "123456789",
+					ScheduledCancellationAt: "2000-01-01",
+				},
+			},
+			wantErr: errors.New("scheduled cancellation must be in the future"),
 		},
 		{
 			name:    "create no req",
@@ -390,17 +500,306 @@ func TestSubscriptionRequest_CancelDelayed(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		if tt.args.stub != nil {
-			tt.args.stub()
-		}
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.args.stub != nil {
+				tt.args.stub()
+			}
 			req := &SubscriptionRequest{
 				Request:       tt.fields.Request,
 				CancelRequest: tt.fields.CancelRequest,
+				ReasonCodes:   tt.fields.ReasonCodes,
 			}
-			if err := req.CancelDelayed(tt.args.client); !reflect.DeepEqual(err, tt.wantErr) {
+			gotResponse, err := req.CancelDelayed(tt.args.client)
+			if !reflect.DeepEqual(err, tt.wantErr) {
 				t.Errorf("SubscriptionRequest.CancelDelayed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("SubscriptionRequest.CancelDelayed() = %v, want %v", gotResponse, tt.wantResponse)
 			}
 		})
 	}
 }
+
+func TestStopDelayedCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	res := &SubscriptionResponse{
+		ID:    123456789,
+		State: "active",
+	}
+	body, err := json.Marshal(res.wrap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name           string
+		subscriptionID int64
+		stub           func()
+		wantResponse   *SubscriptionResponse
+		wantErr        error
+	}{
+		{
+			name:           "stop delayed cancel",
+			subscriptionID: 123456789,
+			stub: func() {
+				client.EXPECT().Delete(nil, "subscriptions/123456789/delayed_cancel.json").Return(&http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message":"ok"}`))),
+				}, nil)
+				client.EXPECT().Get("subscriptions/123456789.json").Return(&http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(bytes.NewReader(body)),
+				}, nil)
+			},
+			wantResponse: res,
+		},
+		{
+			name:           "stop delayed cancel error",
+			subscriptionID: 123456789,
+			stub: func() {
+				client.EXPECT().Delete(nil, "subscriptions/123456789/delayed_cancel.json").Return(&http.Response{
+					StatusCode: 422,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"errors":["mock error"]}`))),
+				}, nil)
+			},
+			wantErr: &Error{
+				Errors: []string{"mock error"},
+			},
+		},
+		{
+			name:    "stop delayed cancel no id",
+			wantErr: NoID(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.stub != nil {
+				tt.stub()
+			}
+			gotResponse, err := StopDelayedCancel(client, tt.subscriptionID)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("StopDelayedCancel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("StopDelayedCancel() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestScheduleProductChange(t *testing.T) {
+	type args struct {
+		client         Client
+		subscriptionID int64
+		change         *ProductChange
+		stub           func()
+	}
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	res := &SubscriptionResponse{
+		ID:                123456789,
+		NextProductHandle: "basic",
+	}
+	body, err := json.Marshal(res.wrap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *SubscriptionResponse
+		wantErr      error
+	}{
+		{
+			name: "schedule",
+			args: args{
+				client:         client,
+				subscriptionID: 123456789,
+				change: &ProductChange{
+					ProductHandle: "basic",
+				},
+				stub: func() {
+					want := []byte(`{"subscription":{"product_handle":"basic","product_change_delayed":true}}`)
+					client.EXPECT().Put(want, "subscriptions/123456789.json").Return(&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewReader(body)),
+					}, nil)
+				},
+			},
+			wantResponse: res,
+		},
+		{
+			name: "schedule no id",
+			args: args{
+				change: &ProductChange{
+					ProductHandle: "basic",
+				},
+			},
+			wantErr: NoID(),
+		},
+		{
+			name: "schedule no product",
+			args: args{
+				subscriptionID: 123456789,
+				change:         &ProductChange{},
+			},
+			wantErr: errors.New("no product id or handle provided"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.args.stub != nil {
+				tt.args.stub()
+			}
+			gotResponse, err := ScheduleProductChange(tt.args.client, tt.args.subscriptionID, tt.args.change)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ScheduleProductChange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResponse, tt.wantResponse) {
+				t.Errorf("ScheduleProductChange() = %v, want %v", gotResponse, tt.wantResponse)
+			}
+			if tt.args.change != nil && tt.args.change.ProductChangeDelayed {
+				t.Errorf("ScheduleProductChange() modified the caller's change")
+			}
+		})
+	}
+}
+
+func TestCancelProductChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	res := &SubscriptionResponse{
+		ID: 123456789,
+	}
+	body, err := json.Marshal(res.wrap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.EXPECT().Put([]byte(`{"subscription":{"next_product_id":""}}`), "subscriptions/123456789.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	}, nil)
+	gotResponse, err := CancelProductChange(client, 123456789)
+	if err != nil {
+		t.Fatalf("CancelProductChange() error = %v", err)
+	}
+	if !reflect.DeepEqual(gotResponse, res) {
+		t.Errorf("CancelProductChange() = %v, want %v", gotResponse, res)
+	}
+	if _, err = CancelProductChange(client, 0); !reflect.DeepEqual(err, NoID()) {
+		t.Errorf("CancelProductChange() error = %v, wantErr %v", err, NoID())
+	}
+}
+
+func TestSubscriptionOverride_validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		override *SubscriptionOverride
+		wantErr  error
+	}{
+		{
+			name: "valid",
+			override: &SubscriptionOverride{
+				ActivatedAt:           "2019-01-01",
+				CurrentPeriodStartsAt: "2020-01-01T00:00:00Z",
+				CanceledAt:            "2020-06-01",
+			},
+		},
+		{
+			name:     "empty",
+			override: &SubscriptionOverride{},
+			wantErr:  errors.New("no dates to override"),
+		},
+		{
+			name: "canceled before activated",
+			override: &SubscriptionOverride{
+				ActivatedAt: "2019-01-01",
+				CanceledAt:  "2018-01-01",
+			},
+			wantErr: errors.New("canceled_at cannot be before activated_at"),
+		},
+		{
+			name: "several before activated",
+			override: &SubscriptionOverride{
+				ActivatedAt:           "2019-01-01",
+				CanceledAt:            "2018-01-01",
+				ExpiresOn:             "2018-01-01",
+				CurrentPeriodStartsAt: "2018-01-01",
+			},
+			wantErr: errors.New("canceled_at cannot be before activated_at"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the reported field must not depend on iteration order
+			for i := 0; i < 10; i++ {
+				if err := tt.override.validate(); !reflect.DeepEqual(err, tt.wantErr) {
+					t.Fatalf("SubscriptionOverride.validate() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestOverrideSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	res := &SubscriptionResponse{
+		ID:          123456789,
+		ActivatedAt: "2019-01-01T00:00:00Z",
+	}
+	body, err := json.Marshal(res.wrap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gomock.InOrder(
+		client.EXPECT().Put([]byte(`{"subscription":{"activated_at":"2019-01-01"}}`), "subscriptions/123456789/override.json").Return(&http.Response{
+			StatusCode: 204,
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		}, nil),
+		client.EXPECT().Get("subscriptions/123456789.json").Return(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		}, nil),
+	)
+	got, err := OverrideSubscription(client, 123456789, &SubscriptionOverride{ActivatedAt: "2019-01-01"})
+	if err != nil {
+		t.Fatalf("OverrideSubscription() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, res) {
+		t.Errorf("OverrideSubscription() = %v, want %v", got, res)
+	}
+	if _, err = OverrideSubscription(client, 123456789, &SubscriptionOverride{}); !reflect.DeepEqual(err, errors.New("no dates to override")) {
+		t.Errorf("OverrideSubscription() error = %v, wantErr no dates to override", err)
+	}
+}
+
+func TestChangeNextBillingDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := test.NewMockClient(ctrl)
+	res := &SubscriptionResponse{
+		ID:               123456789,
+		NextAssessmentAt: "2999-01-01T00:00:00Z",
+	}
+	body, err := json.Marshal(res.wrap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.EXPECT().Put([]byte(`{"subscription":{"next_billing_at":"2999-01-01"}}`), "subscriptions/123456789.json").Return(&http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	}, nil)
+	got, err := ChangeNextBillingDate(client, 123456789, "2999-01-01")
+	if err != nil {
+		t.Fatalf("ChangeNextBillingDate() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, res) {
+		t.Errorf("ChangeNextBillingDate() = %v, want %v", got, res)
+	}
+	if _, err = ChangeNextBillingDate(client, 123456789, "2000-01-01"); !reflect.DeepEqual(err, errors.New("next billing date must be in the future")) {
+		t.Errorf("ChangeNextBillingDate() error = %v, wantErr next billing date must be in the future", err)
+	}
+}
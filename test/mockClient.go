@@ -87,4 +87,29 @@ func (mr *MockClientMockRecorder) Put(arg0, arg1 interface{}) *gomock.Call {
 
 func (mr *MockClient) GenerateSelfServiceLink(arg0 string, arg1 int64) string {
 	return "mock-self-service"
-}
\ No newline at end of file
+}
+
+// SelfServiceURL mocks base method
+func (m *MockClient) SelfServiceURL(arg0 string, arg1 int64) (string, error) {
+	ret := m.ctrl.Call(m, "SelfServiceURL", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelfServiceURL indicates an expected call of SelfServiceURL
+func (mr *MockClientMockRecorder) SelfServiceURL(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelfServiceURL", reflect.TypeOf((*MockClient)(nil).SelfServiceURL), arg0, arg1)
+}
+
+// VerifySelfServiceToken mocks base method
+func (m *MockClient) VerifySelfServiceToken(arg0 string, arg1 int64, arg2 string) bool {
+	ret := m.ctrl.Call(m, "VerifySelfServiceToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// VerifySelfServiceToken indicates an expected call of VerifySelfServiceToken
+func (mr *MockClientMockRecorder) VerifySelfServiceToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySelfServiceToken", reflect.TypeOf((*MockClient)(nil).VerifySelfServiceToken), arg0, arg1, arg2)
+}
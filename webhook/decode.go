@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Unmarshal decodes bracketed form fields such as payload[subscription][customer][email]
+// into v, matching each bracket segment against the json tags of v's fields.
+// Fields that are not present in v are ignored.
+func Unmarshal(values url.Values, prefix string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("webhook: Unmarshal needs a non-nil pointer, got %T", v)
+	}
+	tree := nest(values)
+	src, ok := tree[prefix]
+	if !ok {
+		return nil
+	}
+	return decode(src, rv, prefix)
+}
+
+// nest turns flat bracketed keys into nested maps, e.g. a[b][c]=1 becomes {"a": {"b": {"c": "1"}}}.
+// Keys ending in [] keep every value as a []string.
+func nest(values url.Values) map[string]interface{} {
+	tree := make(map[string]interface{})
+	for key, vals := range values {
+		path := splitKey(key)
+		node := tree
+		for i, segment := range path {
+			last := i == len(path)-1
+			if last || (i == len(path)-2 && path[i+1] == "") {
+				if last {
+					node[segment] = vals[len(vals)-1]
+				} else {
+					node[segment] = vals
+				}
+				break
+			}
+			child, ok := node[segment].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+func splitKey(key string) []string {
+	i := strings.IndexByte(key, '[')
+	if i < 0 {
+		return []string{key}
+	}
+	path := []string{key[:i]}
+	rest := key[i:]
+	for len(rest) > 0 && rest[0] == '[' {
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			break
+		}
+		path = append(path, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return path
+}
+
+func decode(src interface{}, dst reflect.Value, path string) error {
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decode(src, dst.Elem(), path)
+	case reflect.Struct:
+		fields, ok := src.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		t := dst.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			name := strings.TrimSpace(strings.Split(f.Tag.Get("json"), ",")[0])
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			value, ok := fields[name]
+			if !ok {
+				continue
+			}
+			if err := decode(value, dst.Field(i), path+"["+name+"]"); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice:
+		var items []interface{}
+		switch s := src.(type) {
+		case []string:
+			for _, item := range s {
+				items = append(items, item)
+			}
+		case map[string]interface{}:
+			// indexed keys like [transactions][0][id]
+			keys := make([]int, 0, len(s))
+			for k := range s {
+				if n, err := strconv.Atoi(k); err == nil {
+					keys = append(keys, n)
+				}
+			}
+			sort.Ints(keys)
+			for _, k := range keys {
+				items = append(items, s[strconv.Itoa(k)])
+			}
+		default:
+			items = []interface{}{src}
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decode(item, slice.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	}
+	s, ok := src.(string)
+	if !ok || s == "" {
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("webhook: invalid number for %s: %q", path, s)
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("webhook: invalid number for %s: %q", path, s)
+		}
+		dst.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("webhook: invalid number for %s: %q", path, s)
+		}
+		dst.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("webhook: invalid bool for %s: %q", path, s)
+		}
+		dst.SetBool(b)
+	case reflect.Interface:
+		dst.Set(reflect.ValueOf(s))
+	}
+	return nil
+}
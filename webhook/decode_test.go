@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/bchan95/go-chargify"
+)
+
+func TestDecodePayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		body    string
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:  "signup success",
+			event: EventSignupSuccess,
+			body: "id=1&event=signup_success" +
+				"&payload[subscription][id]=456" +
+				"&payload[subscription][state]=active" +
+				"&payload[subscription][cancel_at_end_of_period]=false" +
+				"&payload[subscription][customer][email]=test%40talkatoo.ai" +
+				"&payload[subscription][customer][zip]=12345" +
+				"&payload[subscription][product][handle]=basic" +
+				"&payload[subscription][product][price_in_cents]=1000",
+			want: &SignupSuccess{
+				Subscription: &chargify.SubscriptionResponse{
+					ID:    456,
+					State: "active",
+					Customer: &chargify.CustomerBody{
+						Email: "test@talkatoo.ai",
+						Zip:   "12345",
+					},
+					Product: &chargify.ProductBody{
+						Handle:       "basic",
+						PriceInCents: 1000,
+					},
+				},
+			},
+		},
+		{
+			name:  "payment failure",
+			event: EventPaymentFailure,
+			body: "id=2&event=payment_failure" +
+				"&payload[subscription][id]=456" +
+				"&payload[transaction][id]=789" +
+				"&payload[transaction][success]=false" +
+				"&payload[transaction][amount_in_cents]=1000",
+			want: &PaymentFailure{
+				Subscription: &chargify.SubscriptionResponse{
+					ID: 456,
+				},
+				Transaction: &chargify.Transaction{
+					Id:            789,
+					AmountInCents: 1000,
+				},
+			},
+		},
+		{
+			name:  "statement with transactions",
+			event: EventStatementClosed,
+			body: "id=3&event=statement_closed" +
+				"&payload[statement][id]=10" +
+				"&payload[statement][transactions][1][id]=12" +
+				"&payload[statement][transactions][0][id]=11",
+			want: &StatementClosed{
+				Statement: &chargify.Statement{
+					Id: 10,
+					Transactions: []*chargify.Transaction{
+						{Id: 11},
+						{Id: 12},
+					},
+				},
+			},
+		},
+		{
+			name:  "unknown",
+			event: "component_allocation_change",
+			body:  "id=4&event=component_allocation_change&payload[allocation][quantity]=2",
+			want: &Unknown{
+				Fields: url.Values{
+					"id":                            {"4"},
+					"event":                         {"component_allocation_change"},
+					"payload[allocation][quantity]": {"2"},
+				},
+			},
+		},
+		{
+			name:    "bad number",
+			event:   EventSignupSuccess,
+			body:    "payload[subscription][id]=abc",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := DecodePayload(tt.event, values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodePayload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodePayload() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package webhook
+
+import (
+	"bufio"
+	"container/list"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DedupStore remembers which webhooks have been processed so retried deliveries are only handled once.
+type DedupStore interface {
+	// Claim marks id as processed and reports whether it was new. A false result means the webhook is a duplicate.
+	Claim(id int64) (bool, error)
+	// Release forgets id so a later delivery is processed again, used when the handler fails.
+	Release(id int64) error
+}
+
+// MemoryDedupStore keeps the most recent webhook IDs in memory, evicting the least recently claimed once full.
+type MemoryDedupStore struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	ids   map[int64]*list.Element
+}
+
+func NewMemoryDedupStore(size int) *MemoryDedupStore {
+	return &MemoryDedupStore{
+		size:  size,
+		order: list.New(),
+		ids:   make(map[int64]*list.Element),
+	}
+}
+
+func (s *MemoryDedupStore) Claim(id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.ids[id]; ok {
+		s.order.MoveToFront(e)
+		return false, nil
+	}
+	s.ids[id] = s.order.PushFront(id)
+	for s.size > 0 && s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.ids, oldest.Value.(int64))
+	}
+	return true, nil
+}
+
+func (s *MemoryDedupStore) Release(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.ids[id]; ok {
+		s.order.Remove(e)
+		delete(s.ids, id)
+	}
+	return nil
+}
+
+// FileDedupStore persists claimed webhook IDs in an append-only file so duplicates are caught across restarts.
+// Each line is an ID, or -ID when it was released. The file is not safe to share between processes.
+type FileDedupStore struct {
+	mu  sync.Mutex
+	f   *os.File
+	ids map[int64]bool
+}
+
+func NewFileDedupStore(path string) (*FileDedupStore, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[int64]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("webhook: invalid line in %s: %q", path, line)
+		}
+		if id < 0 {
+			delete(ids, -id)
+			continue
+		}
+		ids[id] = true
+	}
+	if err = scanner.Err(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &FileDedupStore{
+		f:   f,
+		ids: ids,
+	}, nil
+}
+
+func (s *FileDedupStore) Claim(id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ids[id] {
+		return false, nil
+	}
+	if err := s.write(id); err != nil {
+		return false, err
+	}
+	s.ids[id] = true
+	return true, nil
+}
+
+func (s *FileDedupStore) Release(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ids[id] {
+		return nil
+	}
+	if err := s.write(-id); err != nil {
+		return err
+	}
+	delete(s.ids, id)
+	return nil
+}
+
+func (s *FileDedupStore) Close() error {
+	return s.f.Close()
+}
+
+// the id only counts as claimed once it is on disk
+func (s *FileDedupStore) write(id int64) error {
+	if _, err := fmt.Fprintf(s.f, "%d\n", id); err != nil {
+		return err
+	}
+	return s.f.Sync()
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMemoryDedupStore(t *testing.T) {
+	s := NewMemoryDedupStore(2)
+	for _, step := range []struct {
+		id   int64
+		want bool
+	}{
+		{1, true},
+		{1, false},
+		{2, true},
+		{3, true},
+		// 1 was evicted by 3
+		{1, true},
+		{3, false},
+	} {
+		if got, _ := s.Claim(step.id); got != step.want {
+			t.Errorf("MemoryDedupStore.Claim(%d) = %v, want %v", step.id, got, step.want)
+		}
+	}
+	s.Release(3)
+	if got, _ := s.Claim(3); !got {
+		t.Errorf("MemoryDedupStore.Claim(3) after release = %v, want true", got)
+	}
+}
+
+func TestFileDedupStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dedup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "webhooks")
+	s, err := NewFileDedupStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Claim(1)
+	s.Claim(2)
+	s.Release(2)
+	s.Close()
+
+	s, err = NewFileDedupStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if got, _ := s.Claim(1); got {
+		t.Errorf("FileDedupStore.Claim(1) after reopen = %v, want false", got)
+	}
+	if got, _ := s.Claim(2); !got {
+		t.Errorf("FileDedupStore.Claim(2) after reopen = %v, want true", got)
+	}
+}
+
+func TestHandler_ServeHTTP_dedup(t *testing.T) {
+	body := "id=123&event=renewal_success&payload[subscription][id]=456"
+	calls := 0
+	fail := true
+	h := NewHandlerWithKey(testKey)
+	h.SetDedupStore(NewMemoryDedupStore(10))
+	h.OnRenewalSuccess(func(*Event, *RenewalSuccess) error {
+		calls++
+		if fail {
+			fail = false
+			return errors.New("mock error")
+		}
+		return nil
+	})
+	// failed, retried, then a duplicate delivery
+	for _, wantCode := range []int{http.StatusInternalServerError, http.StatusOK, http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		req.Header.Set(SignatureHeader, sign(body))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != wantCode {
+			t.Errorf("ServeHTTP() code = %d, want %d", rec.Code, wantCode)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler calls = %d, want 2", calls)
+	}
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"net/url"
+
+	"github.com/bchan95/go-chargify"
+)
+
+type SignupSuccess struct {
+	Subscription *chargify.SubscriptionResponse `json:"subscription"`
+}
+
+type SignupFailure struct {
+	Subscription *chargify.SubscriptionResponse `json:"subscription"`
+}
+
+type PaymentSuccess struct {
+	Subscription *chargify.SubscriptionResponse `json:"subscription"`
+	Transaction  *chargify.Transaction          `json:"transaction"`
+}
+
+type PaymentFailure struct {
+	Subscription *chargify.SubscriptionResponse `json:"subscription"`
+	Transaction  *chargify.Transaction          `json:"transaction"`
+}
+
+type RenewalSuccess struct {
+	Subscription *chargify.SubscriptionResponse `json:"subscription"`
+}
+
+type RenewalFailure struct {
+	Subscription *chargify.SubscriptionResponse `json:"subscription"`
+}
+
+type SubscriptionStateChange struct {
+	Subscription *chargify.SubscriptionResponse `json:"subscription"`
+}
+
+type SubscriptionProductChange struct {
+	Subscription    *chargify.SubscriptionResponse `json:"subscription"`
+	PreviousProduct *chargify.ProductBody          `json:"previous_product"`
+}
+
+type BillingDateChange struct {
+	Subscription *chargify.SubscriptionResponse `json:"subscription"`
+}
+
+type CustomerUpdate struct {
+	Customer *chargify.CustomerBody `json:"customer"`
+}
+
+type StatementClosed struct {
+	Subscription *chargify.SubscriptionResponse `json:"subscription"`
+	Statement    *chargify.Statement            `json:"statement"`
+}
+
+type StatementSettled struct {
+	Subscription *chargify.SubscriptionResponse `json:"subscription"`
+	Statement    *chargify.Statement            `json:"statement"`
+}
+
+type Test struct {
+	Chargify string `json:"chargify"`
+}
+
+// Unknown is the payload of events this package has no type for. Fields holds every form field of the webhook.
+type Unknown struct {
+	Fields url.Values
+}
+
+var payloads = map[string]func() interface{}{
+	EventSignupSuccess:             func() interface{} { return new(SignupSuccess) },
+	EventSignupFailure:             func() interface{} { return new(SignupFailure) },
+	EventPaymentSuccess:            func() interface{} { return new(PaymentSuccess) },
+	EventPaymentFailure:            func() interface{} { return new(PaymentFailure) },
+	EventRenewalSuccess:            func() interface{} { return new(RenewalSuccess) },
+	EventRenewalFailure:            func() interface{} { return new(RenewalFailure) },
+	EventSubscriptionStateChange:   func() interface{} { return new(SubscriptionStateChange) },
+	EventSubscriptionProductChange: func() interface{} { return new(SubscriptionProductChange) },
+	EventBillingDateChange:         func() interface{} { return new(BillingDateChange) },
+	EventCustomerUpdate:            func() interface{} { return new(CustomerUpdate) },
+	EventStatementClosed:           func() interface{} { return new(StatementClosed) },
+	EventStatementSettled:          func() interface{} { return new(StatementSettled) },
+	EventTest:                      func() interface{} { return new(Test) },
+}
+
+// DecodePayload decodes the payload[...] fields of a webhook into the type registered for event,
+// e.g. *SignupSuccess for signup_success, or *Unknown when there is none.
+func DecodePayload(event string, values url.Values) (interface{}, error) {
+	newPayload, ok := payloads[event]
+	if !ok {
+		return &Unknown{Fields: values}, nil
+	}
+	payload := newPayload()
+	if err := Unmarshal(values, "payload", payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
@@ -0,0 +1,322 @@
+// Package webhook receives Chargify webhooks, verifies their signature and dispatches them by event.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/bchan95/go-chargify"
+)
+
+const SignatureHeader = "X-Chargify-Webhook-Signature-Hmac-Sha-256"
+
+// chargify payloads are small, anything bigger than this is not a webhook
+const maxBodySize = 1 << 20
+
+const (
+	EventSignupSuccess             = "signup_success"
+	EventSignupFailure             = "signup_failure"
+	EventPaymentSuccess            = "payment_success"
+	EventPaymentFailure            = "payment_failure"
+	EventRenewalSuccess            = "renewal_success"
+	EventRenewalFailure            = "renewal_failure"
+	EventSubscriptionStateChange   = "subscription_state_change"
+	EventSubscriptionProductChange = "subscription_product_change"
+	EventBillingDateChange         = "billing_date_change"
+	EventCustomerUpdate            = "customer_update"
+	EventStatementClosed           = "statement_closed"
+	EventStatementSettled          = "statement_settled"
+	EventTest                      = "test"
+)
+
+var InvalidSignature = errors.New("invalid webhook signature")
+
+type Event struct {
+	ID      int64
+	Name    string
+	Payload url.Values
+	// Data is the decoded payload, e.g. *SignupSuccess, or *Unknown for events without a type.
+	Data interface{}
+	// DecodeErr is set when the payload did not fit the event's type, Data is *Unknown then.
+	// Typed handlers are skipped for these events and the HandleUnknown handler gets them instead.
+	DecodeErr error
+}
+
+// HandlerFunc processes a single webhook. Returning an error responds with a 500 so Chargify retries the delivery.
+type HandlerFunc func(*Event) error
+
+type Handler struct {
+	key      string
+	handlers map[string]HandlerFunc
+	fallback HandlerFunc
+	dedup    DedupStore
+}
+
+// NewHandler creates a Handler using the site shared key from CHARGIFY_SITE_SHARED_KEY.
+func NewHandler() (*Handler, error) {
+	key, err := chargify.SiteSharedKey()
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, errors.New("CHARGIFY_SITE_SHARED_KEY env not found")
+	}
+	return NewHandlerWithKey(key), nil
+}
+
+func NewHandlerWithKey(key string) *Handler {
+	return &Handler{
+		key:      key,
+		handlers: make(map[string]HandlerFunc),
+	}
+}
+
+// Handle registers fn for the named event, replacing any previous handler.
+func (h *Handler) Handle(event string, fn HandlerFunc) {
+	h.handlers[event] = fn
+}
+
+// HandleUnknown registers fn for events without a handler of their own. Without it they are acknowledged and dropped.
+func (h *Handler) HandleUnknown(fn HandlerFunc) {
+	h.fallback = fn
+}
+
+// SetDedupStore makes the handler skip webhooks whose ID was already claimed in store.
+// Duplicates are acknowledged without calling any handler.
+func (h *Handler) SetDedupStore(store DedupStore) {
+	h.dedup = store
+}
+
+func (h *Handler) OnSignupSuccess(fn func(*Event, *SignupSuccess) error) {
+	h.Handle(EventSignupSuccess, func(e *Event) error {
+		data, ok := e.Data.(*SignupSuccess)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnSignupFailure(fn func(*Event, *SignupFailure) error) {
+	h.Handle(EventSignupFailure, func(e *Event) error {
+		data, ok := e.Data.(*SignupFailure)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnPaymentSuccess(fn func(*Event, *PaymentSuccess) error) {
+	h.Handle(EventPaymentSuccess, func(e *Event) error {
+		data, ok := e.Data.(*PaymentSuccess)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnPaymentFailure(fn func(*Event, *PaymentFailure) error) {
+	h.Handle(EventPaymentFailure, func(e *Event) error {
+		data, ok := e.Data.(*PaymentFailure)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnRenewalSuccess(fn func(*Event, *RenewalSuccess) error) {
+	h.Handle(EventRenewalSuccess, func(e *Event) error {
+		data, ok := e.Data.(*RenewalSuccess)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnRenewalFailure(fn func(*Event, *RenewalFailure) error) {
+	h.Handle(EventRenewalFailure, func(e *Event) error {
+		data, ok := e.Data.(*RenewalFailure)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnSubscriptionProductChange(fn func(*Event, *SubscriptionProductChange) error) {
+	h.Handle(EventSubscriptionProductChange, func(e *Event) error {
+		data, ok := e.Data.(*SubscriptionProductChange)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnBillingDateChange(fn func(*Event, *BillingDateChange) error) {
+	h.Handle(EventBillingDateChange, func(e *Event) error {
+		data, ok := e.Data.(*BillingDateChange)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnCustomerUpdate(fn func(*Event, *CustomerUpdate) error) {
+	h.Handle(EventCustomerUpdate, func(e *Event) error {
+		data, ok := e.Data.(*CustomerUpdate)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnStatementClosed(fn func(*Event, *StatementClosed) error) {
+	h.Handle(EventStatementClosed, func(e *Event) error {
+		data, ok := e.Data.(*StatementClosed)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnStatementSettled(fn func(*Event, *StatementSettled) error) {
+	h.Handle(EventStatementSettled, func(e *Event) error {
+		data, ok := e.Data.(*StatementSettled)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+func (h *Handler) OnSubscriptionStateChange(fn func(*Event, *SubscriptionStateChange) error) {
+	h.Handle(EventSubscriptionStateChange, func(e *Event) error {
+		data, ok := e.Data.(*SubscriptionStateChange)
+		if !ok {
+			return h.undecoded(e)
+		}
+		return fn(e, data)
+	})
+}
+
+// passes an event whose payload could not be decoded to the HandleUnknown handler, or drops it when there is none
+func (h *Handler) undecoded(e *Event) error {
+	if h.fallback != nil {
+		return h.fallback(e)
+	}
+	log.Printf("webhook %d (%s): dropped, %v", e.ID, e.Name, e.DecodeErr)
+	return nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err = Verify(h.key, body, r.Header.Get(SignatureHeader)); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	event, err := parse(body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fn, ok := h.handlers[event.Name]
+	if !ok {
+		fn = h.fallback
+	}
+	if fn == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if h.dedup != nil {
+		var claimed bool
+		claimed, err = h.dedup.Claim(event.ID)
+		if err != nil {
+			log.Printf("webhook %d (%s): %v", event.ID, event.Name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if !claimed {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+	if err = fn(event); err != nil {
+		log.Printf("webhook %d (%s): %v", event.ID, event.Name, err)
+		if h.dedup != nil {
+			// let chargify's retry process it again
+			if releaseErr := h.dedup.Release(event.ID); releaseErr != nil {
+				log.Printf("webhook %d (%s): %v", event.ID, event.Name, releaseErr)
+			}
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Verify checks the HMAC-SHA256 signature Chargify sends with every webhook against the raw request body.
+func Verify(key string, body []byte, signature string) error {
+	if signature == "" {
+		return InvalidSignature
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return InvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return InvalidSignature
+	}
+	return nil
+}
+
+func parse(body []byte) (*Event, error) {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+	name := values.Get("event")
+	if name == "" {
+		return nil, errors.New("no event provided")
+	}
+	id, err := strconv.ParseInt(values.Get("id"), 10, 64)
+	if err != nil {
+		return nil, chargify.NoID()
+	}
+	event := &Event{
+		ID:      id,
+		Name:    name,
+		Payload: values,
+	}
+	event.Data, event.DecodeErr = DecodePayload(name, values)
+	if event.DecodeErr != nil {
+		// a signed webhook is never rejected, chargify would keep retrying it
+		event.Data = &Unknown{Fields: values}
+	}
+	return event, nil
+}
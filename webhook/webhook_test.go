@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testKey = "mock-shared-key"
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(testKey))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	body := "id=123&event=signup_success&payload[subscription][id]=456"
+	tests := []struct {
+		name      string
+		method    string
+		signature string
+		handler   HandlerFunc
+		wantCode  int
+		wantCall  bool
+	}{
+		{
+			name:      "dispatch",
+			method:    http.MethodPost,
+			signature: sign(body),
+			handler:   func(*Event) error { return nil },
+			wantCode:  http.StatusOK,
+			wantCall:  true,
+		},
+		{
+			name:      "bad signature",
+			method:    http.MethodPost,
+			signature: sign("tampered"),
+			handler:   func(*Event) error { return nil },
+			wantCode:  http.StatusUnauthorized,
+		},
+		{
+			name:      "handler error",
+			method:    http.MethodPost,
+			signature: sign(body),
+			handler:   func(*Event) error { return errors.New("mock error") },
+			wantCode:  http.StatusInternalServerError,
+			wantCall:  true,
+		},
+		{
+			name:     "get",
+			method:   http.MethodGet,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			h := NewHandlerWithKey(testKey)
+			h.OnSignupSuccess(func(e *Event, payload *SignupSuccess) error {
+				called = true
+				if e.ID != 123 || payload.Subscription.ID != 456 {
+					t.Errorf("unexpected event %+v", e)
+				}
+				return tt.handler(e)
+			})
+			req := httptest.NewRequest(tt.method, "/webhooks", strings.NewReader(body))
+			req.Header.Set(SignatureHeader, tt.signature)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Errorf("ServeHTTP() code = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if called != tt.wantCall {
+				t.Errorf("ServeHTTP() called = %v, want %v", called, tt.wantCall)
+			}
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_undecodable(t *testing.T) {
+	body := "id=123&event=signup_success&payload[subscription][id]=12.5"
+	tests := []struct {
+		name         string
+		withFallback bool
+	}{
+		{
+			name:         "fallback",
+			withFallback: true,
+		},
+		{
+			name: "no fallback",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandlerWithKey(testKey)
+			store := NewMemoryDedupStore(10)
+			h.SetDedupStore(store)
+			h.OnSignupSuccess(func(*Event, *SignupSuccess) error {
+				t.Error("typed handler called with an undecodable payload")
+				return nil
+			})
+			var got *Event
+			if tt.withFallback {
+				h.HandleUnknown(func(e *Event) error {
+					got = e
+					return nil
+				})
+			}
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+			req.Header.Set(SignatureHeader, sign(body))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Errorf("ServeHTTP() code = %d, want %d", rec.Code, http.StatusOK)
+			}
+			if claimed, _ := store.Claim(123); claimed {
+				t.Error("ServeHTTP() did not record the webhook in the dedup store")
+			}
+			if !tt.withFallback {
+				return
+			}
+			if got == nil {
+				t.Fatal("ServeHTTP() did not call the HandleUnknown handler")
+			}
+			unknown, ok := got.Data.(*Unknown)
+			if got.DecodeErr == nil || !ok || unknown.Fields.Get("payload[subscription][id]") != "12.5" {
+				t.Errorf("ServeHTTP() event = %+v, want raw fields and a decode error", got)
+			}
+		})
+	}
+}